@@ -0,0 +1,437 @@
+package fft
+
+import (
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// Data for a 1D signal.
+type Array[T Complex] struct {
+	Elems []T
+}
+
+func NewArray[T Complex](n int) *Array[T] {
+	return &Array[T]{make([]T, n)}
+}
+
+func (a *Array[T]) Len() int {
+	return len(a.Elems)
+}
+
+func (a *Array[T]) At(i int) T {
+	return a.Elems[i]
+}
+
+func (a *Array[T]) Set(i int, x T) {
+	a.Elems[i] = x
+}
+
+func (a *Array[T]) c128() *fftw.Array {
+	if a == nil {
+		return nil
+	}
+	return &fftw.Array{Elems: any(a.Elems).([]complex128)}
+}
+
+func (a *Array[T]) c64() *fftw32.Array {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.Array{Elems: any(a.Elems).([]complex64)}
+}
+
+// Data for a real 1D signal.
+type RealArray[F Float] struct {
+	Elems []F
+}
+
+func NewRealArray[F Float](n int) *RealArray[F] {
+	return &RealArray[F]{make([]F, n)}
+}
+
+func (a *RealArray[F]) Len() int {
+	return len(a.Elems)
+}
+
+func (a *RealArray[F]) At(i int) F {
+	return a.Elems[i]
+}
+
+func (a *RealArray[F]) Set(i int, x F) {
+	a.Elems[i] = x
+}
+
+func (a *RealArray[F]) r64() *fftw.RealArray {
+	if a == nil {
+		return nil
+	}
+	return &fftw.RealArray{Elems: any(a.Elems).([]float64)}
+}
+
+func (a *RealArray[F]) r32() *fftw32.RealArray {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.RealArray{Elems: any(a.Elems).([]float32)}
+}
+
+// 2D version of Array.
+type Array2[T Complex] struct {
+	N     [2]int
+	Elems []T
+}
+
+func NewArray2[T Complex](n0, n1 int) *Array2[T] {
+	return &Array2[T]{[...]int{n0, n1}, make([]T, n0*n1)}
+}
+
+func (a *Array2[T]) Dims() (int, int) {
+	return a.N[0], a.N[1]
+}
+
+func (a *Array2[T]) At(i0, i1 int) T {
+	return a.Elems[i1+a.N[1]*i0]
+}
+
+func (a *Array2[T]) Set(i0, i1 int, x T) {
+	a.Elems[i1+a.N[1]*i0] = x
+}
+
+func (a *Array2[T]) Slice() [][]T {
+	x := a.Elems
+	s := make([][]T, a.N[0])
+	for i := range s {
+		s[i], x = x[:a.N[1]], x[a.N[1]:]
+	}
+	return s
+}
+
+func (a *Array2[T]) c128() *fftw.Array2 {
+	if a == nil {
+		return nil
+	}
+	return &fftw.Array2{N: a.N, Elems: any(a.Elems).([]complex128)}
+}
+
+func (a *Array2[T]) c64() *fftw32.Array2 {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.Array2{N: a.N, Elems: any(a.Elems).([]complex64)}
+}
+
+// 2D version of RealArray.
+//
+// If Padded is set, each row is followed by padding up to 2*(N[1]/2+1) elements,
+// which is the layout FFTW uses for in-place real-to-complex transforms.
+type RealArray2[F Float] struct {
+	N      [2]int
+	Elems  []F
+	Padded bool
+}
+
+func NewRealArray2[F Float](n0, n1 int) *RealArray2[F] {
+	return &RealArray2[F]{[...]int{n0, n1}, make([]F, n0*n1), false}
+}
+
+// NewRealArray2Padded allocates an n0 x n1 real array with padded rows.
+// Use Complex to obtain the n0 x (n1/2+1) complex array that shares its memory.
+func NewRealArray2Padded[F Float](n0, n1 int) *RealArray2[F] {
+	return &RealArray2[F]{[...]int{n0, n1}, make([]F, n0*rowLen(n1, true)), true}
+}
+
+func (a *RealArray2[F]) Dims() (int, int) {
+	return a.N[0], a.N[1]
+}
+
+func (a *RealArray2[F]) At(i0, i1 int) F {
+	return a.Elems[i1+rowLen(a.N[1], a.Padded)*i0]
+}
+
+func (a *RealArray2[F]) Set(i0, i1 int, x F) {
+	a.Elems[i1+rowLen(a.N[1], a.Padded)*i0] = x
+}
+
+// Slice returns the rows of a, excluding any padding.
+func (a *RealArray2[F]) Slice() [][]F {
+	x := a.Elems
+	row := rowLen(a.N[1], a.Padded)
+	s := make([][]F, a.N[0])
+	for i := range s {
+		s[i], x = x[:a.N[1]], x[row:]
+	}
+	return s
+}
+
+// Complex2 returns the n0 x (n1/2+1) complex array that shares the memory of the
+// padded array a, for use with in-place real transforms. It is a function rather
+// than a method because T cannot be inferred; it must have the precision of F.
+func Complex2[T Complex, F Float](a *RealArray2[F]) *Array2[T] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		c := a.r64().Complex()
+		return &Array2[T]{c.N, any(c.Elems).([]T)}
+	}
+	c := a.r32().Complex()
+	return &Array2[T]{c.N, any(c.Elems).([]T)}
+}
+
+func (a *RealArray2[F]) r64() *fftw.RealArray2 {
+	if a == nil {
+		return nil
+	}
+	return &fftw.RealArray2{N: a.N, Elems: any(a.Elems).([]float64), Padded: a.Padded}
+}
+
+func (a *RealArray2[F]) r32() *fftw32.RealArray2 {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.RealArray2{N: a.N, Elems: any(a.Elems).([]float32), Padded: a.Padded}
+}
+
+// 3D version of Array.
+type Array3[T Complex] struct {
+	N     [3]int
+	Elems []T
+}
+
+func NewArray3[T Complex](n0, n1, n2 int) *Array3[T] {
+	return &Array3[T]{[...]int{n0, n1, n2}, make([]T, n0*n1*n2)}
+}
+
+func (a *Array3[T]) Dims() (int, int, int) {
+	return a.N[0], a.N[1], a.N[2]
+}
+
+func (a *Array3[T]) At(i0, i1, i2 int) T {
+	return a.Elems[i2+a.N[2]*(i1+a.N[1]*i0)]
+}
+
+func (a *Array3[T]) Set(i0, i1, i2 int, x T) {
+	a.Elems[i2+a.N[2]*(i1+a.N[1]*i0)] = x
+}
+
+func (a *Array3[T]) Slice() [][][]T {
+	x := a.Elems
+	s := make([][][]T, a.N[0])
+	for i := range s {
+		s[i] = make([][]T, a.N[1])
+		for j := range s[i] {
+			s[i][j], x = x[:a.N[2]], x[a.N[2]:]
+		}
+	}
+	return s
+}
+
+func (a *Array3[T]) c128() *fftw.Array3 {
+	if a == nil {
+		return nil
+	}
+	return &fftw.Array3{N: a.N, Elems: any(a.Elems).([]complex128)}
+}
+
+func (a *Array3[T]) c64() *fftw32.Array3 {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.Array3{N: a.N, Elems: any(a.Elems).([]complex64)}
+}
+
+// 3D version of RealArray.
+//
+// If Padded is set, the last dimension is padded to 2*(N[2]/2+1) elements.
+type RealArray3[F Float] struct {
+	N      [3]int
+	Elems  []F
+	Padded bool
+}
+
+func NewRealArray3[F Float](n0, n1, n2 int) *RealArray3[F] {
+	return &RealArray3[F]{[...]int{n0, n1, n2}, make([]F, n0*n1*n2), false}
+}
+
+// NewRealArray3Padded allocates an n0 x n1 x n2 real array with a padded last dimension.
+// Use Complex3 to obtain the n0 x n1 x (n2/2+1) complex array that shares its memory.
+func NewRealArray3Padded[F Float](n0, n1, n2 int) *RealArray3[F] {
+	return &RealArray3[F]{[...]int{n0, n1, n2}, make([]F, n0*n1*rowLen(n2, true)), true}
+}
+
+func (a *RealArray3[F]) Dims() (int, int, int) {
+	return a.N[0], a.N[1], a.N[2]
+}
+
+func (a *RealArray3[F]) At(i0, i1, i2 int) F {
+	return a.Elems[i2+rowLen(a.N[2], a.Padded)*(i1+a.N[1]*i0)]
+}
+
+func (a *RealArray3[F]) Set(i0, i1, i2 int, x F) {
+	a.Elems[i2+rowLen(a.N[2], a.Padded)*(i1+a.N[1]*i0)] = x
+}
+
+// Slice returns the rows of a, excluding any padding.
+func (a *RealArray3[F]) Slice() [][][]F {
+	x := a.Elems
+	row := rowLen(a.N[2], a.Padded)
+	s := make([][][]F, a.N[0])
+	for i := range s {
+		s[i] = make([][]F, a.N[1])
+		for j := range s[i] {
+			s[i][j], x = x[:a.N[2]], x[row:]
+		}
+	}
+	return s
+}
+
+// 3D version of Complex2.
+func Complex3[T Complex, F Float](a *RealArray3[F]) *Array3[T] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		c := a.r64().Complex()
+		return &Array3[T]{c.N, any(c.Elems).([]T)}
+	}
+	c := a.r32().Complex()
+	return &Array3[T]{c.N, any(c.Elems).([]T)}
+}
+
+func (a *RealArray3[F]) r64() *fftw.RealArray3 {
+	if a == nil {
+		return nil
+	}
+	return &fftw.RealArray3{N: a.N, Elems: any(a.Elems).([]float64), Padded: a.Padded}
+}
+
+func (a *RealArray3[F]) r32() *fftw32.RealArray3 {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.RealArray3{N: a.N, Elems: any(a.Elems).([]float32), Padded: a.Padded}
+}
+
+// N-dimensional version of Array.
+type ArrayN[T Complex] struct {
+	N     []int
+	Elems []T
+}
+
+func NewArrayN[T Complex](n []int) *ArrayN[T] {
+	return &ArrayN[T]{append([]int(nil), n...), make([]T, prod(n))}
+}
+
+func (a *ArrayN[T]) Dims() []int {
+	return a.N
+}
+
+func (a *ArrayN[T]) At(i []int) T {
+	return a.Elems[index(a.N, i)]
+}
+
+func (a *ArrayN[T]) Set(i []int, x T) {
+	a.Elems[index(a.N, i)] = x
+}
+
+func (a *ArrayN[T]) c128() *fftw.ArrayN {
+	if a == nil {
+		return nil
+	}
+	return &fftw.ArrayN{N: a.N, Elems: any(a.Elems).([]complex128)}
+}
+
+func (a *ArrayN[T]) c64() *fftw32.ArrayN {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.ArrayN{N: a.N, Elems: any(a.Elems).([]complex64)}
+}
+
+// N-dimensional version of RealArray.
+//
+// If Padded is set, the last dimension is padded to 2*(n/2+1) elements.
+type RealArrayN[F Float] struct {
+	N      []int
+	Elems  []F
+	Padded bool
+}
+
+func NewRealArrayN[F Float](n []int) *RealArrayN[F] {
+	return &RealArrayN[F]{append([]int(nil), n...), make([]F, prod(n)), false}
+}
+
+// NewRealArrayNPadded allocates a real array with a padded last dimension.
+// Use ComplexN to obtain the complex array, with last dimension n/2+1, that shares its memory.
+func NewRealArrayNPadded[F Float](n []int) *RealArrayN[F] {
+	a := &RealArrayN[F]{append([]int(nil), n...), nil, true}
+	a.Elems = make([]F, prod(a.paddedDims()))
+	return a
+}
+
+func (a *RealArrayN[F]) Dims() []int {
+	return a.N
+}
+
+func (a *RealArrayN[F]) At(i []int) F {
+	return a.Elems[index(a.paddedDims(), i)]
+}
+
+func (a *RealArrayN[F]) Set(i []int, x F) {
+	a.Elems[index(a.paddedDims(), i)] = x
+}
+
+// N-dimensional version of Complex2.
+func ComplexN[T Complex, F Float](a *RealArrayN[F]) *ArrayN[T] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		c := a.r64().Complex()
+		return &ArrayN[T]{c.N, any(c.Elems).([]T)}
+	}
+	c := a.r32().Complex()
+	return &ArrayN[T]{c.N, any(c.Elems).([]T)}
+}
+
+// paddedDims returns the dimensions of the memory layout of a.
+func (a *RealArrayN[F]) paddedDims() []int {
+	n := append([]int(nil), a.N...)
+	if len(n) > 0 {
+		n[len(n)-1] = rowLen(n[len(n)-1], a.Padded)
+	}
+	return n
+}
+
+func (a *RealArrayN[F]) r64() *fftw.RealArrayN {
+	if a == nil {
+		return nil
+	}
+	return &fftw.RealArrayN{N: a.N, Elems: any(a.Elems).([]float64), Padded: a.Padded}
+}
+
+func (a *RealArrayN[F]) r32() *fftw32.RealArrayN {
+	if a == nil {
+		return nil
+	}
+	return &fftw32.RealArrayN{N: a.N, Elems: any(a.Elems).([]float32), Padded: a.Padded}
+}
+
+func prod(x []int) int {
+	p := 1
+	for _, n := range x {
+		p *= n
+	}
+	return p
+}
+
+// index returns the row-major offset of i in an array with dimensions n.
+func index(n, i []int) int {
+	var m int
+	for d := range n {
+		m = m*n[d] + i[d]
+	}
+	return m
+}
+
+// rowLen returns the number of elements of a real row of length n.
+func rowLen(n int, padded bool) int {
+	if padded {
+		return 2 * (n/2 + 1)
+	}
+	return n
+}
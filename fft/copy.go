@@ -0,0 +1,23 @@
+package fft
+
+import (
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// CopySlice2 copies the rows of src to dst, returning ErrJaggedArray if they
+// differ in length and ErrDimensionsMismatch if they do not fit dst.
+func CopySlice2[T Complex](dst *Array2[T], src [][]T) error {
+	if double[T]() {
+		return fftw.CopySlice2(dst.c128(), any(src).([][]complex128))
+	}
+	return convertError(fftw32.CopySlice2(dst.c64(), any(src).([][]complex64)))
+}
+
+// 3D version of CopySlice2.
+func CopySlice3[T Complex](dst *Array3[T], src [][][]T) error {
+	if double[T]() {
+		return fftw.CopySlice3(dst.c128(), any(src).([][][]complex128))
+	}
+	return convertError(fftw32.CopySlice3(dst.c64(), any(src).([][][]complex64)))
+}
@@ -0,0 +1,25 @@
+/*
+Package fft is a precision-agnostic layer over packages fftw and fftw32.
+
+Its arrays, plans and helpers are generic over the element type, complex64 or
+complex128 for complex data and float32 or float64 for real data, and dispatch
+to fftwf or fftw under the hood, so that code can be written once and
+instantiated for either precision.
+
+	func spectrum[T fft.Complex](x []T) []T {
+		return fft.FFT(&fft.Array[T]{Elems: x}).Elems
+	}
+
+The arrays share the layout, and their Elems the memory, of the arrays of the
+precision packages. Transforms between real and complex data take both element
+types, which must have the same precision:
+
+	x := fft.NewRealArray[float32](100)
+	xhat := fft.RFFT[complex64](x)
+
+Plans come in three flavors: Plan for complex DFTs, RealPlan for real-to-complex
+and complex-to-real transforms, and R2RPlan for real-to-real transforms. Batched,
+guru and split plans, wisdom and threads are only available from the precision
+packages.
+*/
+package fft
@@ -0,0 +1,389 @@
+package fft
+
+import (
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// FFT computes the Fourier transform of src.
+// It allocates memory in which to return the result.
+func FFT[T Complex](src *Array[T]) *Array[T] {
+	dst := NewArray[T](src.Len())
+	FFTTo(dst, src)
+
+	return dst
+}
+
+// FFTTo computes the Fourier transform of src
+// and returns the result in dst.
+func FFTTo[T Complex](dst, src *Array[T]) {
+	if double[T]() {
+		fftw.FFTTo(dst.c128(), src.c128())
+	} else {
+		fftw32.FFTTo(dst.c64(), src.c64())
+	}
+}
+
+// IFFT computes the inverse Fourier transform of src.
+// It allocates memory in which to return the result.
+func IFFT[T Complex](src *Array[T]) *Array[T] {
+	dst := NewArray[T](src.Len())
+	IFFTTo(dst, src)
+
+	return dst
+}
+
+// IFFTTo computes the inverse Fourier transform of src
+// and returns the result in dst.
+func IFFTTo[T Complex](dst, src *Array[T]) {
+	if double[T]() {
+		fftw.IFFTTo(dst.c128(), src.c128())
+	} else {
+		fftw32.IFFTTo(dst.c64(), src.c64())
+	}
+}
+
+// 2D version of FFT.
+func FFT2[T Complex](src *Array2[T]) *Array2[T] {
+	dst := NewArray2[T](src.Dims())
+	FFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of FFTTo.
+func FFT2To[T Complex](dst, src *Array2[T]) {
+	if double[T]() {
+		fftw.FFT2To(dst.c128(), src.c128())
+	} else {
+		fftw32.FFT2To(dst.c64(), src.c64())
+	}
+}
+
+// 2D version of IFFT.
+func IFFT2[T Complex](src *Array2[T]) *Array2[T] {
+	dst := NewArray2[T](src.Dims())
+	IFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of IFFTTo.
+func IFFT2To[T Complex](dst, src *Array2[T]) {
+	if double[T]() {
+		fftw.IFFT2To(dst.c128(), src.c128())
+	} else {
+		fftw32.IFFT2To(dst.c64(), src.c64())
+	}
+}
+
+// 3D version of FFT.
+func FFT3[T Complex](src *Array3[T]) *Array3[T] {
+	dst := NewArray3[T](src.Dims())
+	FFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of FFTTo.
+func FFT3To[T Complex](dst, src *Array3[T]) {
+	if double[T]() {
+		fftw.FFT3To(dst.c128(), src.c128())
+	} else {
+		fftw32.FFT3To(dst.c64(), src.c64())
+	}
+}
+
+// 3D version of IFFT.
+func IFFT3[T Complex](src *Array3[T]) *Array3[T] {
+	dst := NewArray3[T](src.Dims())
+	IFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of IFFTTo.
+func IFFT3To[T Complex](dst, src *Array3[T]) {
+	if double[T]() {
+		fftw.IFFT3To(dst.c128(), src.c128())
+	} else {
+		fftw32.IFFT3To(dst.c64(), src.c64())
+	}
+}
+
+// N-dimensional version of FFT.
+func FFTN[T Complex](src *ArrayN[T]) *ArrayN[T] {
+	dst := NewArrayN[T](src.Dims())
+	FFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of FFTTo.
+func FFTNTo[T Complex](dst, src *ArrayN[T]) {
+	if double[T]() {
+		fftw.FFTNTo(dst.c128(), src.c128())
+	} else {
+		fftw32.FFTNTo(dst.c64(), src.c64())
+	}
+}
+
+// N-dimensional version of IFFT.
+func IFFTN[T Complex](src *ArrayN[T]) *ArrayN[T] {
+	dst := NewArrayN[T](src.Dims())
+	IFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of IFFTTo.
+func IFFTNTo[T Complex](dst, src *ArrayN[T]) {
+	if double[T]() {
+		fftw.IFFTNTo(dst.c128(), src.c128())
+	} else {
+		fftw32.IFFTNTo(dst.c64(), src.c64())
+	}
+}
+
+// RFFT computes the Fourier transform of the real signal src.
+// It allocates memory in which to return the n/2+1 non-negative frequency terms.
+//
+// T cannot be inferred and must be given, as in RFFT[complex64](src).
+func RFFT[T Complex, F Float](src *RealArray[F]) *Array[T] {
+	dst := NewArray[T](src.Len()/2 + 1)
+	RFFTTo(dst, src)
+
+	return dst
+}
+
+// IRFFT computes the inverse Fourier transform of the n/2+1 element half-spectrum
+// src and returns the real signal of length n.
+//
+// F cannot be inferred and must be given, as in IRFFT[float32](src, n).
+func IRFFT[F Float, T Complex](src *Array[T], n int) *RealArray[F] {
+	dst := NewRealArray[F](n)
+	IRFFTTo(dst, src)
+
+	return dst
+}
+
+// RFFTTo computes the Fourier transform of the real signal src
+// and returns the n/2+1 non-negative frequency terms in dst.
+func RFFTTo[T Complex, F Float](dst *Array[T], src *RealArray[F]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFTTo(dst.c128(), src.r64())
+	} else {
+		fftw32.RFFTTo(dst.c64(), src.r32())
+	}
+}
+
+// IRFFTTo computes the inverse Fourier transform of the half-spectrum src
+// and returns the real signal in dst, leaving src intact.
+func IRFFTTo[F Float, T Complex](dst *RealArray[F], src *Array[T]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFTTo(dst.r64(), src.c128())
+	} else {
+		fftw32.IRFFTTo(dst.r32(), src.c64())
+	}
+}
+
+// 2D version of RFFT.
+func RFFT2[T Complex, F Float](src *RealArray2[F]) *Array2[T] {
+	n0, n1 := src.Dims()
+	dst := NewArray2[T](n0, n1/2+1)
+	RFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of IRFFT, where n1 is the length of the real rows.
+func IRFFT2[F Float, T Complex](src *Array2[T], n1 int) *RealArray2[F] {
+	n0, _ := src.Dims()
+	dst := NewRealArray2[F](n0, n1)
+	IRFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of RFFTTo.
+func RFFT2To[T Complex, F Float](dst *Array2[T], src *RealArray2[F]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFT2To(dst.c128(), src.r64())
+	} else {
+		fftw32.RFFT2To(dst.c64(), src.r32())
+	}
+}
+
+// 2D version of IRFFTTo.
+func IRFFT2To[F Float, T Complex](dst *RealArray2[F], src *Array2[T]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFT2To(dst.r64(), src.c128())
+	} else {
+		fftw32.IRFFT2To(dst.r32(), src.c64())
+	}
+}
+
+// 3D version of RFFT.
+func RFFT3[T Complex, F Float](src *RealArray3[F]) *Array3[T] {
+	n0, n1, n2 := src.Dims()
+	dst := NewArray3[T](n0, n1, n2/2+1)
+	RFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of IRFFT, where n2 is the length of the real rows.
+func IRFFT3[F Float, T Complex](src *Array3[T], n2 int) *RealArray3[F] {
+	n0, n1, _ := src.Dims()
+	dst := NewRealArray3[F](n0, n1, n2)
+	IRFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of RFFTTo.
+func RFFT3To[T Complex, F Float](dst *Array3[T], src *RealArray3[F]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFT3To(dst.c128(), src.r64())
+	} else {
+		fftw32.RFFT3To(dst.c64(), src.r32())
+	}
+}
+
+// 3D version of IRFFTTo.
+func IRFFT3To[F Float, T Complex](dst *RealArray3[F], src *Array3[T]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFT3To(dst.r64(), src.c128())
+	} else {
+		fftw32.IRFFT3To(dst.r32(), src.c64())
+	}
+}
+
+// N-dimensional version of RFFT.
+func RFFTN[T Complex, F Float](src *RealArrayN[F]) *ArrayN[T] {
+	dst := NewArrayN[T](halfDims(src.Dims()))
+	RFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of IRFFT, where n is the length of the real rows.
+func IRFFTN[F Float, T Complex](src *ArrayN[T], n int) *RealArrayN[F] {
+	dims := append([]int(nil), src.Dims()...)
+	if len(dims) > 0 {
+		dims[len(dims)-1] = n
+	}
+	dst := NewRealArrayN[F](dims)
+	IRFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of RFFTTo.
+func RFFTNTo[T Complex, F Float](dst *ArrayN[T], src *RealArrayN[F]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFTNTo(dst.c128(), src.r64())
+	} else {
+		fftw32.RFFTNTo(dst.c64(), src.r32())
+	}
+}
+
+// N-dimensional version of IRFFTTo.
+func IRFFTNTo[F Float, T Complex](dst *RealArrayN[F], src *ArrayN[T]) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFTNTo(dst.r64(), src.c128())
+	} else {
+		fftw32.IRFFTNTo(dst.r32(), src.c64())
+	}
+}
+
+// DCT computes the type-II discrete cosine transform (REDFT10) of src,
+// without normalization.
+// It allocates memory in which to return the result.
+func DCT[F Float](src *RealArray[F]) *RealArray[F] {
+	dst := NewRealArray[F](src.Len())
+	DCTTo(dst, src)
+
+	return dst
+}
+
+// DCTTo computes the type-II discrete cosine transform of src into dst.
+func DCTTo[F Float](dst, src *RealArray[F]) {
+	if doubleReal[F]() {
+		fftw.DCTTo(dst.r64(), src.r64())
+	} else {
+		fftw32.DCTTo(dst.r32(), src.r32())
+	}
+}
+
+// IDCT computes the inverse of DCT, so that IDCT(DCT(x)) reproduces x.
+// It allocates memory in which to return the result.
+func IDCT[F Float](src *RealArray[F]) *RealArray[F] {
+	dst := NewRealArray[F](src.Len())
+	IDCTTo(dst, src)
+
+	return dst
+}
+
+// IDCTTo computes the inverse of DCT of src into dst.
+func IDCTTo[F Float](dst, src *RealArray[F]) {
+	if doubleReal[F]() {
+		fftw.IDCTTo(dst.r64(), src.r64())
+	} else {
+		fftw32.IDCTTo(dst.r32(), src.r32())
+	}
+}
+
+// DST computes the type-II discrete sine transform (RODFT10) of src,
+// without normalization.
+// It allocates memory in which to return the result.
+func DST[F Float](src *RealArray[F]) *RealArray[F] {
+	dst := NewRealArray[F](src.Len())
+	DSTTo(dst, src)
+
+	return dst
+}
+
+// DSTTo computes the type-II discrete sine transform of src into dst.
+func DSTTo[F Float](dst, src *RealArray[F]) {
+	if doubleReal[F]() {
+		fftw.DSTTo(dst.r64(), src.r64())
+	} else {
+		fftw32.DSTTo(dst.r32(), src.r32())
+	}
+}
+
+// IDST computes the inverse of DST, so that IDST(DST(x)) reproduces x.
+// It allocates memory in which to return the result.
+func IDST[F Float](src *RealArray[F]) *RealArray[F] {
+	dst := NewRealArray[F](src.Len())
+	IDSTTo(dst, src)
+
+	return dst
+}
+
+// IDSTTo computes the inverse of DST of src into dst.
+func IDSTTo[F Float](dst, src *RealArray[F]) {
+	if doubleReal[F]() {
+		fftw.IDSTTo(dst.r64(), src.r64())
+	} else {
+		fftw32.IDSTTo(dst.r32(), src.r32())
+	}
+}
+
+// halfDims returns the dimensions of the half-spectrum of a real array with dimensions n.
+func halfDims(n []int) []int {
+	h := append([]int(nil), n...)
+	if len(h) > 0 {
+		h[len(h)-1] = h[len(h)-1]/2 + 1
+	}
+	return h
+}
@@ -0,0 +1,270 @@
+package fft
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+)
+
+// tolerance returns the absolute tolerance of the tests for precision T.
+func tolerance[T Complex]() float64 {
+	if double[T]() {
+		return 1e-10
+	}
+	return 1e-4
+}
+
+func testClose[T Complex](t *testing.T, got, want T) {
+	t.Helper()
+
+	if cmplx.Abs(complex128(got)-complex128(want)) > tolerance[T]()*(1+cmplx.Abs(complex128(want))) {
+		t.Fatalf("expected %v to be almost equal to %v", got, want)
+	}
+}
+
+func testCloseReal[F Float](t *testing.T, got, want F) {
+	t.Helper()
+
+	tol := 1e-4
+	if doubleReal[F]() {
+		tol = 1e-10
+	}
+	if math.Abs(float64(got-want)) > tol*(1+math.Abs(float64(want))) {
+		t.Fatalf("expected %v to be almost equal to %v", got, want)
+	}
+}
+
+func cosine[T Complex](n int) *Array[T] {
+	a := NewArray[T](n)
+	for i := range a.Elems {
+		a.Elems[i] = T(complex(math.Cos(float64(i)/float64(n)*math.Pi*2), 0))
+	}
+	return a
+}
+
+func testFFT[T Complex](t *testing.T) {
+	const n = 16
+
+	dst := FFT(cosine[T](n))
+	for i, x := range dst.Elems {
+		var want T
+		if i == 1 || i == n-1 {
+			want = n / 2
+		}
+		testClose(t, x, want)
+	}
+
+	back := IFFT(dst)
+	for i, x := range back.Elems {
+		testClose(t, x, n*cosine[T](n).Elems[i])
+	}
+}
+
+func TestFFT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testFFT[complex64])
+	t.Run("complex128", testFFT[complex128])
+}
+
+func testNorm[T Complex, F Float](t *testing.T) {
+	const n = 16
+
+	half := RFFTNorm[T](NewRealArray[F](n), NormForward)
+	if len(half.Elems) != n/2+1 {
+		t.Fatalf("RFFTNorm returned %d elements, want %d", len(half.Elems), n/2+1)
+	}
+
+	dst := FFTNorm(cosine[T](n), NormOrtho)
+	testClose(t, dst.Elems[1], T(complex(n/2/math.Sqrt(n), 0)))
+
+	back := IFFTNorm(dst, NormOrtho)
+	for i, x := range back.Elems {
+		testClose(t, x, cosine[T](n).Elems[i])
+	}
+
+	// A plan scales with the size of the whole transform.
+	src := NewArray2[T](2, 4)
+	for i := range src.Elems {
+		src.Elems[i] = 1
+	}
+	p := NewPlan2(src, src, Forward, Estimate)
+	defer p.Destroy()
+
+	p.ExecuteNormalized(NormForward)
+	testClose(t, src.Elems[0], 1)
+}
+
+func TestNorm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testNorm[complex64, float32])
+	t.Run("complex128", testNorm[complex128, float64])
+}
+
+func testFFTN[T Complex](t *testing.T) {
+	dims := []int{2, 3, 4}
+
+	src := NewArrayN[T](dims)
+	for i := range src.Elems {
+		src.Elems[i] = T(complex(float64(i%5), -float64(i%3)))
+	}
+
+	back := IFFTN(FFTN(src))
+	for i := range src.Elems {
+		testClose(t, back.Elems[i], 24*src.Elems[i])
+	}
+
+	a3 := &Array3[T]{N: [3]int{2, 3, 4}, Elems: src.Elems}
+	got := FFT3(a3)
+	want := FFTN(src)
+	for i := range want.Elems {
+		testClose(t, got.Elems[i], want.Elems[i])
+	}
+}
+
+func TestFFTN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testFFTN[complex64])
+	t.Run("complex128", testFFTN[complex128])
+}
+
+func testRFFT[T Complex, F Float](t *testing.T) {
+	const n = 10
+
+	src := NewRealArray[F](n)
+	for i := range src.Elems {
+		src.Elems[i] = F(math.Sin(float64(i)) + 0.5)
+	}
+
+	spec := RFFT[T](src)
+	full := NewArray[T](n)
+	for i := range src.Elems {
+		full.Elems[i] = T(complex(float64(src.Elems[i]), 0))
+	}
+	want := FFT(full)
+
+	for i := range spec.Elems {
+		testClose(t, spec.Elems[i], want.Elems[i])
+	}
+
+	back := IRFFT[F](spec, n)
+	for i := range back.Elems {
+		testCloseReal(t, back.Elems[i], n*src.Elems[i])
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testRFFT[complex64, float32])
+	t.Run("complex128", testRFFT[complex128, float64])
+}
+
+func testInPlaceR2C2[T Complex, F Float](t *testing.T) {
+	const n0, n1 = 4, 6
+
+	a := NewRealArray2Padded[F](n0, n1)
+	ref := NewRealArray2[F](n0, n1)
+	for i := range n0 {
+		for j := range n1 {
+			a.Set(i, j, F(i-j*j))
+			ref.Set(i, j, F(i-j*j))
+		}
+	}
+
+	c := Complex2[T](a)
+	NewPlanR2C2(a, c, Estimate).Execute().Destroy()
+
+	want := RFFT2[T](ref)
+	for i := range want.Elems {
+		testClose(t, c.Elems[i], want.Elems[i])
+	}
+}
+
+func TestInPlaceR2C2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testInPlaceR2C2[complex64, float32])
+	t.Run("complex128", testInPlaceR2C2[complex128, float64])
+}
+
+func testDCT[F Float](t *testing.T) {
+	src := NewRealArray[F](8)
+	for i := range src.Elems {
+		src.Elems[i] = F(i * i)
+	}
+
+	back := IDCT(DCT(src))
+	for i := range src.Elems {
+		testCloseReal(t, back.Elems[i], src.Elems[i])
+	}
+
+	p := NewPlanR2R(src, NewRealArray[F](8), REDFT10, Estimate)
+	defer p.Destroy()
+
+	dst := NewRealArray[F](8)
+	if err := p.ExecuteR2ROn(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := DCT(src)
+	for i := range want.Elems {
+		testCloseReal(t, dst.Elems[i], want.Elems[i])
+	}
+}
+
+func TestDCT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("float32", testDCT[float32])
+	t.Run("float64", testDCT[float64])
+}
+
+func testPlanErrors[T Complex](t *testing.T) {
+	p := NewPlan2(NewArray2[T](4, 6), NewArray2[T](4, 6), Forward, Estimate)
+	defer p.Destroy()
+
+	if !strings.Contains(p.String(), "(") {
+		t.Fatalf("unexpected plan description %q", p.String())
+	}
+
+	if err := p.ExecuteOn2(NewArray2[T](4, 6), NewArray2[T](6, 4)); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch, got %v", err)
+	}
+
+	a := NewArray2[T](4, 6)
+	if err := p.ExecuteOn2(a, a); !errors.Is(err, ErrInPlace) {
+		t.Fatalf("expected ErrInPlace, got %v", err)
+	}
+
+	if _, err := TryNewPlan(NewArray[T](0), NewArray[T](0), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	jagged := [][]T{make([]T, 6), make([]T, 5)}
+	if err := CopySlice2(NewArray2[T](2, 6), jagged); !errors.Is(err, ErrJaggedArray) {
+		t.Fatalf("expected ErrJaggedArray, got %v", err)
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complex64", testPlanErrors[complex64])
+	t.Run("complex128", testPlanErrors[complex128])
+}
+
+func TestPrecisionMismatch(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for mixed precisions")
+		}
+	}()
+
+	RFFT[complex128](NewRealArray[float32](8))
+}
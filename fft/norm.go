@@ -0,0 +1,325 @@
+package fft
+
+import (
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// Norm selects the scaling of a transform; see fftw.Norm.
+type Norm = fftw.Norm
+
+const (
+	NormBackward = fftw.NormBackward
+	NormOrtho    = fftw.NormOrtho
+	NormForward  = fftw.NormForward
+)
+
+func (h *handle) executeNormalized(norm Norm) {
+	if h.p128 != nil {
+		h.p128.ExecuteNormalized(norm)
+	} else {
+		h.p64.ExecuteNormalized(fftw32.Norm(norm))
+	}
+}
+
+// ExecuteNormalized executes the plan p and scales its output as norm requires,
+// as fftw.Plan.ExecuteNormalized.
+func (p *Plan[T]) ExecuteNormalized(norm Norm) *Plan[T] {
+	p.executeNormalized(norm)
+	return p
+}
+
+// ExecuteNormalized executes the plan p and scales its output as norm requires,
+// as fftw.Plan.ExecuteNormalized.
+func (p *RealPlan[T, F]) ExecuteNormalized(norm Norm) *RealPlan[T, F] {
+	p.executeNormalized(norm)
+	return p
+}
+
+// FFTNorm is the version of FFT that scales the result as norm requires.
+func FFTNorm[T Complex](src *Array[T], norm Norm) *Array[T] {
+	dst := NewArray[T](src.Len())
+	FFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// FFTNormTo is the version of FFTTo that scales the result as norm requires.
+func FFTNormTo[T Complex](dst, src *Array[T], norm Norm) {
+	if double[T]() {
+		fftw.FFTNormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.FFTNormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// IFFTNorm is the version of IFFT that scales the result as norm requires.
+func IFFTNorm[T Complex](src *Array[T], norm Norm) *Array[T] {
+	dst := NewArray[T](src.Len())
+	IFFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// IFFTNormTo is the version of IFFTTo that scales the result as norm requires.
+func IFFTNormTo[T Complex](dst, src *Array[T], norm Norm) {
+	if double[T]() {
+		fftw.IFFTNormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.IFFTNormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 2D version of FFTNorm.
+func FFT2Norm[T Complex](src *Array2[T], norm Norm) *Array2[T] {
+	dst := NewArray2[T](src.Dims())
+	FFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of FFTNormTo.
+func FFT2NormTo[T Complex](dst, src *Array2[T], norm Norm) {
+	if double[T]() {
+		fftw.FFT2NormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.FFT2NormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 2D version of IFFTNorm.
+func IFFT2Norm[T Complex](src *Array2[T], norm Norm) *Array2[T] {
+	dst := NewArray2[T](src.Dims())
+	IFFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of IFFTNormTo.
+func IFFT2NormTo[T Complex](dst, src *Array2[T], norm Norm) {
+	if double[T]() {
+		fftw.IFFT2NormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.IFFT2NormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 3D version of FFTNorm.
+func FFT3Norm[T Complex](src *Array3[T], norm Norm) *Array3[T] {
+	dst := NewArray3[T](src.Dims())
+	FFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of FFTNormTo.
+func FFT3NormTo[T Complex](dst, src *Array3[T], norm Norm) {
+	if double[T]() {
+		fftw.FFT3NormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.FFT3NormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 3D version of IFFTNorm.
+func IFFT3Norm[T Complex](src *Array3[T], norm Norm) *Array3[T] {
+	dst := NewArray3[T](src.Dims())
+	IFFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of IFFTNormTo.
+func IFFT3NormTo[T Complex](dst, src *Array3[T], norm Norm) {
+	if double[T]() {
+		fftw.IFFT3NormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.IFFT3NormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// N-dimensional version of FFTNorm.
+func FFTNNorm[T Complex](src *ArrayN[T], norm Norm) *ArrayN[T] {
+	dst := NewArrayN[T](src.Dims())
+	FFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of FFTNormTo.
+func FFTNNormTo[T Complex](dst, src *ArrayN[T], norm Norm) {
+	if double[T]() {
+		fftw.FFTNNormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.FFTNNormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// N-dimensional version of IFFTNorm.
+func IFFTNNorm[T Complex](src *ArrayN[T], norm Norm) *ArrayN[T] {
+	dst := NewArrayN[T](src.Dims())
+	IFFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of IFFTNormTo.
+func IFFTNNormTo[T Complex](dst, src *ArrayN[T], norm Norm) {
+	if double[T]() {
+		fftw.IFFTNNormTo(dst.c128(), src.c128(), norm)
+	} else {
+		fftw32.IFFTNNormTo(dst.c64(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// RFFTNorm is the version of RFFT that scales the result as norm requires.
+func RFFTNorm[T Complex, F Float](src *RealArray[F], norm Norm) *Array[T] {
+	dst := NewArray[T](src.Len()/2 + 1)
+	RFFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// RFFTNormTo is the version of RFFTTo that scales the result as norm requires.
+func RFFTNormTo[T Complex, F Float](dst *Array[T], src *RealArray[F], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFTNormTo(dst.c128(), src.r64(), norm)
+	} else {
+		fftw32.RFFTNormTo(dst.c64(), src.r32(), fftw32.Norm(norm))
+	}
+}
+
+// IRFFTNorm is the version of IRFFT that scales the result as norm requires.
+func IRFFTNorm[F Float, T Complex](src *Array[T], n int, norm Norm) *RealArray[F] {
+	dst := NewRealArray[F](n)
+	IRFFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// IRFFTNormTo is the version of IRFFTTo that scales the result as norm requires.
+func IRFFTNormTo[F Float, T Complex](dst *RealArray[F], src *Array[T], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFTNormTo(dst.r64(), src.c128(), norm)
+	} else {
+		fftw32.IRFFTNormTo(dst.r32(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 2D version of RFFTNorm.
+func RFFT2Norm[T Complex, F Float](src *RealArray2[F], norm Norm) *Array2[T] {
+	n0, n1 := src.Dims()
+	dst := NewArray2[T](n0, n1/2+1)
+	RFFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of RFFTNormTo.
+func RFFT2NormTo[T Complex, F Float](dst *Array2[T], src *RealArray2[F], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFT2NormTo(dst.c128(), src.r64(), norm)
+	} else {
+		fftw32.RFFT2NormTo(dst.c64(), src.r32(), fftw32.Norm(norm))
+	}
+}
+
+// 2D version of IRFFTNorm.
+func IRFFT2Norm[F Float, T Complex](src *Array2[T], n1 int, norm Norm) *RealArray2[F] {
+	n0, _ := src.Dims()
+	dst := NewRealArray2[F](n0, n1)
+	IRFFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of IRFFTNormTo.
+func IRFFT2NormTo[F Float, T Complex](dst *RealArray2[F], src *Array2[T], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFT2NormTo(dst.r64(), src.c128(), norm)
+	} else {
+		fftw32.IRFFT2NormTo(dst.r32(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// 3D version of RFFTNorm.
+func RFFT3Norm[T Complex, F Float](src *RealArray3[F], norm Norm) *Array3[T] {
+	n0, n1, n2 := src.Dims()
+	dst := NewArray3[T](n0, n1, n2/2+1)
+	RFFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of RFFTNormTo.
+func RFFT3NormTo[T Complex, F Float](dst *Array3[T], src *RealArray3[F], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFT3NormTo(dst.c128(), src.r64(), norm)
+	} else {
+		fftw32.RFFT3NormTo(dst.c64(), src.r32(), fftw32.Norm(norm))
+	}
+}
+
+// 3D version of IRFFTNorm.
+func IRFFT3Norm[F Float, T Complex](src *Array3[T], n2 int, norm Norm) *RealArray3[F] {
+	n0, n1, _ := src.Dims()
+	dst := NewRealArray3[F](n0, n1, n2)
+	IRFFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of IRFFTNormTo.
+func IRFFT3NormTo[F Float, T Complex](dst *RealArray3[F], src *Array3[T], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFT3NormTo(dst.r64(), src.c128(), norm)
+	} else {
+		fftw32.IRFFT3NormTo(dst.r32(), src.c64(), fftw32.Norm(norm))
+	}
+}
+
+// N-dimensional version of RFFTNorm.
+func RFFTNNorm[T Complex, F Float](src *RealArrayN[F], norm Norm) *ArrayN[T] {
+	dst := NewArrayN[T](halfDims(src.Dims()))
+	RFFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of RFFTNormTo.
+func RFFTNNormTo[T Complex, F Float](dst *ArrayN[T], src *RealArrayN[F], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.RFFTNNormTo(dst.c128(), src.r64(), norm)
+	} else {
+		fftw32.RFFTNNormTo(dst.c64(), src.r32(), fftw32.Norm(norm))
+	}
+}
+
+// N-dimensional version of IRFFTNorm.
+func IRFFTNNorm[F Float, T Complex](src *ArrayN[T], n int, norm Norm) *RealArrayN[F] {
+	dims := append([]int(nil), src.Dims()...)
+	if len(dims) > 0 {
+		dims[len(dims)-1] = n
+	}
+	dst := NewRealArrayN[F](dims)
+	IRFFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of IRFFTNormTo.
+func IRFFTNNormTo[F Float, T Complex](dst *RealArrayN[F], src *ArrayN[T], norm Norm) {
+	checkPrecision[T, F]()
+	if double[T]() {
+		fftw.IRFFTNNormTo(dst.r64(), src.c128(), norm)
+	} else {
+		fftw32.IRFFTNNormTo(dst.r32(), src.c64(), fftw32.Norm(norm))
+	}
+}
@@ -0,0 +1,195 @@
+package fft
+
+import (
+	"io"
+
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// handle is the plan of the precision package that does the work. Exactly one
+// of its fields is set.
+type handle struct {
+	p128 *fftw.Plan
+	p64  *fftw32.Plan
+}
+
+func (h *handle) execute() {
+	if h.p128 != nil {
+		h.p128.Execute()
+	} else {
+		h.p64.Execute()
+	}
+}
+
+// Destroy releases the plan. It is safe to call more than once.
+func (h *handle) Destroy() {
+	if h.p128 != nil {
+		h.p128.Destroy()
+	} else {
+		h.p64.Destroy()
+	}
+}
+
+// String returns FFTW's textual description of the plan.
+func (h *handle) String() string {
+	if h.p128 != nil {
+		return h.p128.String()
+	}
+	return h.p64.String()
+}
+
+// Flops returns the number of additions, multiplications and fused
+// multiply-adds that executing the plan takes.
+func (h *handle) Flops() (add, mul, fma float64) {
+	if h.p128 != nil {
+		return h.p128.Flops()
+	}
+	return h.p64.Flops()
+}
+
+// Cost returns the planner's measured cost of the plan, or 0 if it was not measured.
+func (h *handle) Cost() float64 {
+	if h.p128 != nil {
+		return h.p128.Cost()
+	}
+	return h.p64.Cost()
+}
+
+// EstimateCost returns the planner's estimate of the cost of the plan.
+func (h *handle) EstimateCost() float64 {
+	if h.p128 != nil {
+		return h.p128.EstimateCost()
+	}
+	return h.p64.EstimateCost()
+}
+
+// WriteTo writes the description of the plan returned by String to w.
+func (h *handle) WriteTo(w io.Writer) (int64, error) {
+	if h.p128 != nil {
+		n, err := h.p128.WriteTo(w)
+		return n, convertError(err)
+	}
+	n, err := h.p64.WriteTo(w)
+	return n, convertError(err)
+}
+
+// Plan is a plan for the complex DFT of arrays with elements of type T.
+type Plan[T Complex] struct {
+	handle
+}
+
+// NewPlan returns a plan for the DFT of in, written to out.
+// It panics if the arrays are unsuitable or FFTW cannot create the plan;
+// TryNewPlan returns an error instead.
+func NewPlan[T Complex](in, out *Array[T], dir Direction, flag Flag) *Plan[T] {
+	return mustPlan(TryNewPlan(in, out, dir, flag))
+}
+
+// 2D version of NewPlan.
+func NewPlan2[T Complex](in, out *Array2[T], dir Direction, flag Flag) *Plan[T] {
+	return mustPlan(TryNewPlan2(in, out, dir, flag))
+}
+
+// 3D version of NewPlan.
+func NewPlan3[T Complex](in, out *Array3[T], dir Direction, flag Flag) *Plan[T] {
+	return mustPlan(TryNewPlan3(in, out, dir, flag))
+}
+
+// N-dimensional version of NewPlan.
+func NewPlanN[T Complex](in, out *ArrayN[T], dir Direction, flag Flag) *Plan[T] {
+	return mustPlan(TryNewPlanN(in, out, dir, flag))
+}
+
+// TryNewPlan is the version of NewPlan that returns an error instead of panicking,
+// with the same errors as fftw.TryNewPlan.
+func TryNewPlan[T Complex](in, out *Array[T], dir Direction, flag Flag) (*Plan[T], error) {
+	if double[T]() {
+		return newPlan[T](fftw.TryNewPlan(in.c128(), out.c128(), dir, flag))
+	}
+	return newPlan32[T](fftw32.TryNewPlan(in.c64(), out.c64(), fftw32.Direction(dir), fftw32.Flag(flag)))
+}
+
+// 2D version of TryNewPlan.
+func TryNewPlan2[T Complex](in, out *Array2[T], dir Direction, flag Flag) (*Plan[T], error) {
+	if double[T]() {
+		return newPlan[T](fftw.TryNewPlan2(in.c128(), out.c128(), dir, flag))
+	}
+	return newPlan32[T](fftw32.TryNewPlan2(in.c64(), out.c64(), fftw32.Direction(dir), fftw32.Flag(flag)))
+}
+
+// 3D version of TryNewPlan.
+func TryNewPlan3[T Complex](in, out *Array3[T], dir Direction, flag Flag) (*Plan[T], error) {
+	if double[T]() {
+		return newPlan[T](fftw.TryNewPlan3(in.c128(), out.c128(), dir, flag))
+	}
+	return newPlan32[T](fftw32.TryNewPlan3(in.c64(), out.c64(), fftw32.Direction(dir), fftw32.Flag(flag)))
+}
+
+// N-dimensional version of TryNewPlan.
+func TryNewPlanN[T Complex](in, out *ArrayN[T], dir Direction, flag Flag) (*Plan[T], error) {
+	if double[T]() {
+		return newPlan[T](fftw.TryNewPlanN(in.c128(), out.c128(), dir, flag))
+	}
+	return newPlan32[T](fftw32.TryNewPlanN(in.c64(), out.c64(), fftw32.Direction(dir), fftw32.Flag(flag)))
+}
+
+func newPlan[T Complex](p *fftw.Plan, err error) (*Plan[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Plan[T]{handle{p128: p, p64: nil}}, nil
+}
+
+func newPlan32[T Complex](p *fftw32.Plan, err error) (*Plan[T], error) {
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return &Plan[T]{handle{p128: nil, p64: p}}, nil
+}
+
+// mustPlan panics if err is not nil, for the constructors that panic on error.
+func mustPlan[P any](p P, err error) P {
+	if err != nil {
+		panic("fft: " + err.Error())
+	}
+	return p
+}
+
+func (p *Plan[T]) Execute() *Plan[T] {
+	p.execute()
+	return p
+}
+
+// ExecuteOn executes the plan p on the arrays in and out instead of the arrays
+// it was created for, under the conditions of fftw.Plan.ExecuteOn.
+func (p *Plan[T]) ExecuteOn(in, out *Array[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteOn(in.c128(), out.c128())
+	}
+	return convertError(p.p64.ExecuteOn(in.c64(), out.c64()))
+}
+
+// 2D version of ExecuteOn, for plans created by NewPlan2.
+func (p *Plan[T]) ExecuteOn2(in, out *Array2[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteOn2(in.c128(), out.c128())
+	}
+	return convertError(p.p64.ExecuteOn2(in.c64(), out.c64()))
+}
+
+// 3D version of ExecuteOn, for plans created by NewPlan3.
+func (p *Plan[T]) ExecuteOn3(in, out *Array3[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteOn3(in.c128(), out.c128())
+	}
+	return convertError(p.p64.ExecuteOn3(in.c64(), out.c64()))
+}
+
+// N-dimensional version of ExecuteOn, for plans created by NewPlanN.
+func (p *Plan[T]) ExecuteOnN(in, out *ArrayN[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteOnN(in.c128(), out.c128())
+	}
+	return convertError(p.p64.ExecuteOnN(in.c64(), out.c64()))
+}
@@ -0,0 +1,241 @@
+package fft
+
+import (
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// RealPlan is a plan for a transform between real arrays with elements of type F
+// and complex arrays with elements of type T, which must have the same precision.
+type RealPlan[T Complex, F Float] struct {
+	handle
+}
+
+func (p *RealPlan[T, F]) Execute() *RealPlan[T, F] {
+	p.execute()
+	return p
+}
+
+// NewPlanR2C returns a plan for the forward transform of the real signal in
+// to the n/2+1 element half-spectrum out, as fftw.NewPlanR2C.
+func NewPlanR2C[T Complex, F Float](in *RealArray[F], out *Array[T], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanR2C(in.r64(), out.c128(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanR2C(in.r32(), out.c64(), fftw32.Flag(flag))}}
+}
+
+// 2D version of NewPlanR2C.
+func NewPlanR2C2[T Complex, F Float](in *RealArray2[F], out *Array2[T], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanR2C2(in.r64(), out.c128(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanR2C2(in.r32(), out.c64(), fftw32.Flag(flag))}}
+}
+
+// 3D version of NewPlanR2C.
+func NewPlanR2C3[T Complex, F Float](in *RealArray3[F], out *Array3[T], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanR2C3(in.r64(), out.c128(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanR2C3(in.r32(), out.c64(), fftw32.Flag(flag))}}
+}
+
+// N-dimensional version of NewPlanR2C.
+func NewPlanR2CN[T Complex, F Float](in *RealArrayN[F], out *ArrayN[T], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanR2CN(in.r64(), out.c128(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanR2CN(in.r32(), out.c64(), fftw32.Flag(flag))}}
+}
+
+// NewPlanC2R returns a plan for the backward transform of the n/2+1 element
+// half-spectrum in to the real signal out, as fftw.NewPlanC2R. Executing it
+// overwrites the input.
+func NewPlanC2R[T Complex, F Float](in *Array[T], out *RealArray[F], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanC2R(in.c128(), out.r64(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanC2R(in.c64(), out.r32(), fftw32.Flag(flag))}}
+}
+
+// 2D version of NewPlanC2R.
+func NewPlanC2R2[T Complex, F Float](in *Array2[T], out *RealArray2[F], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanC2R2(in.c128(), out.r64(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanC2R2(in.c64(), out.r32(), fftw32.Flag(flag))}}
+}
+
+// 3D version of NewPlanC2R.
+func NewPlanC2R3[T Complex, F Float](in *Array3[T], out *RealArray3[F], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanC2R3(in.c128(), out.r64(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanC2R3(in.c64(), out.r32(), fftw32.Flag(flag))}}
+}
+
+// N-dimensional version of NewPlanC2R.
+func NewPlanC2RN[T Complex, F Float](in *ArrayN[T], out *RealArrayN[F], flag Flag) *RealPlan[T, F] {
+	checkPrecision[T, F]()
+	if double[T]() {
+		return &RealPlan[T, F]{handle{p128: fftw.NewPlanC2RN(in.c128(), out.r64(), flag), p64: nil}}
+	}
+	return &RealPlan[T, F]{handle{p128: nil, p64: fftw32.NewPlanC2RN(in.c64(), out.r32(), fftw32.Flag(flag))}}
+}
+
+// ExecuteR2COn is the version of Plan.ExecuteOn for plans created by NewPlanR2C.
+func (p *RealPlan[T, F]) ExecuteR2COn(in *RealArray[F], out *Array[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2COn(in.r64(), out.c128())
+	}
+	return convertError(p.p64.ExecuteR2COn(in.r32(), out.c64()))
+}
+
+// 2D version of ExecuteR2COn, for plans created by NewPlanR2C2.
+func (p *RealPlan[T, F]) ExecuteR2COn2(in *RealArray2[F], out *Array2[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2COn2(in.r64(), out.c128())
+	}
+	return convertError(p.p64.ExecuteR2COn2(in.r32(), out.c64()))
+}
+
+// 3D version of ExecuteR2COn, for plans created by NewPlanR2C3.
+func (p *RealPlan[T, F]) ExecuteR2COn3(in *RealArray3[F], out *Array3[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2COn3(in.r64(), out.c128())
+	}
+	return convertError(p.p64.ExecuteR2COn3(in.r32(), out.c64()))
+}
+
+// N-dimensional version of ExecuteR2COn, for plans created by NewPlanR2CN.
+func (p *RealPlan[T, F]) ExecuteR2COnN(in *RealArrayN[F], out *ArrayN[T]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2COnN(in.r64(), out.c128())
+	}
+	return convertError(p.p64.ExecuteR2COnN(in.r32(), out.c64()))
+}
+
+// ExecuteC2ROn is the version of Plan.ExecuteOn for plans created by NewPlanC2R.
+// Like Execute, it overwrites the input.
+func (p *RealPlan[T, F]) ExecuteC2ROn(in *Array[T], out *RealArray[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteC2ROn(in.c128(), out.r64())
+	}
+	return convertError(p.p64.ExecuteC2ROn(in.c64(), out.r32()))
+}
+
+// 2D version of ExecuteC2ROn, for plans created by NewPlanC2R2.
+func (p *RealPlan[T, F]) ExecuteC2ROn2(in *Array2[T], out *RealArray2[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteC2ROn2(in.c128(), out.r64())
+	}
+	return convertError(p.p64.ExecuteC2ROn2(in.c64(), out.r32()))
+}
+
+// 3D version of ExecuteC2ROn, for plans created by NewPlanC2R3.
+func (p *RealPlan[T, F]) ExecuteC2ROn3(in *Array3[T], out *RealArray3[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteC2ROn3(in.c128(), out.r64())
+	}
+	return convertError(p.p64.ExecuteC2ROn3(in.c64(), out.r32()))
+}
+
+// N-dimensional version of ExecuteC2ROn, for plans created by NewPlanC2RN.
+func (p *RealPlan[T, F]) ExecuteC2ROnN(in *ArrayN[T], out *RealArrayN[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteC2ROnN(in.c128(), out.r64())
+	}
+	return convertError(p.p64.ExecuteC2ROnN(in.c64(), out.r32()))
+}
+
+// R2RPlan is a plan for a real-to-real transform of arrays with elements of type F.
+type R2RPlan[F Float] struct {
+	handle
+}
+
+func (p *R2RPlan[F]) Execute() *R2RPlan[F] {
+	p.execute()
+	return p
+}
+
+// NewPlanR2R returns a plan for the real-to-real transform of the given kind of
+// in, written to out.
+func NewPlanR2R[F Float](in, out *RealArray[F], kind Kind, flag Flag) *R2RPlan[F] {
+	if doubleReal[F]() {
+		return &R2RPlan[F]{handle{p128: fftw.NewPlanR2R(in.r64(), out.r64(), kind, flag), p64: nil}}
+	}
+	p := fftw32.NewPlanR2R(in.r32(), out.r32(), fftw32.Kind(kind), fftw32.Flag(flag))
+	return &R2RPlan[F]{handle{p128: nil, p64: p}}
+}
+
+// 2D version of NewPlanR2R, applying kind0 along the first dimension and kind1
+// along the second.
+func NewPlanR2R2[F Float](in, out *RealArray2[F], kind0, kind1 Kind, flag Flag) *R2RPlan[F] {
+	if doubleReal[F]() {
+		return &R2RPlan[F]{handle{p128: fftw.NewPlanR2R2(in.r64(), out.r64(), kind0, kind1, flag), p64: nil}}
+	}
+	p := fftw32.NewPlanR2R2(in.r32(), out.r32(), fftw32.Kind(kind0), fftw32.Kind(kind1), fftw32.Flag(flag))
+	return &R2RPlan[F]{handle{p128: nil, p64: p}}
+}
+
+// 3D version of NewPlanR2R.
+func NewPlanR2R3[F Float](in, out *RealArray3[F], kind0, kind1, kind2 Kind, flag Flag) *R2RPlan[F] {
+	if doubleReal[F]() {
+		return &R2RPlan[F]{handle{p128: fftw.NewPlanR2R3(in.r64(), out.r64(), kind0, kind1, kind2, flag), p64: nil}}
+	}
+	p := fftw32.NewPlanR2R3(in.r32(), out.r32(), fftw32.Kind(kind0), fftw32.Kind(kind1), fftw32.Kind(kind2),
+		fftw32.Flag(flag))
+	return &R2RPlan[F]{handle{p128: nil, p64: p}}
+}
+
+// N-dimensional version of NewPlanR2R, applying kinds[i] along dimension i.
+func NewPlanR2RN[F Float](in, out *RealArrayN[F], kinds []Kind, flag Flag) *R2RPlan[F] {
+	if doubleReal[F]() {
+		return &R2RPlan[F]{handle{p128: fftw.NewPlanR2RN(in.r64(), out.r64(), kinds, flag), p64: nil}}
+	}
+	kinds32 := make([]fftw32.Kind, len(kinds))
+	for i, k := range kinds {
+		kinds32[i] = fftw32.Kind(k)
+	}
+	return &R2RPlan[F]{handle{p128: nil, p64: fftw32.NewPlanR2RN(in.r32(), out.r32(), kinds32, fftw32.Flag(flag))}}
+}
+
+// ExecuteR2ROn is the version of Plan.ExecuteOn for plans created by NewPlanR2R.
+func (p *R2RPlan[F]) ExecuteR2ROn(in, out *RealArray[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2ROn(in.r64(), out.r64())
+	}
+	return convertError(p.p64.ExecuteR2ROn(in.r32(), out.r32()))
+}
+
+// 2D version of ExecuteR2ROn, for plans created by NewPlanR2R2.
+func (p *R2RPlan[F]) ExecuteR2ROn2(in, out *RealArray2[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2ROn2(in.r64(), out.r64())
+	}
+	return convertError(p.p64.ExecuteR2ROn2(in.r32(), out.r32()))
+}
+
+// 3D version of ExecuteR2ROn, for plans created by NewPlanR2R3.
+func (p *R2RPlan[F]) ExecuteR2ROn3(in, out *RealArray3[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2ROn3(in.r64(), out.r64())
+	}
+	return convertError(p.p64.ExecuteR2ROn3(in.r32(), out.r32()))
+}
+
+// N-dimensional version of ExecuteR2ROn, for plans created by NewPlanR2RN.
+func (p *R2RPlan[F]) ExecuteR2ROnN(in, out *RealArrayN[F]) error {
+	if p.p128 != nil {
+		return p.p128.ExecuteR2ROnN(in.r64(), out.r64())
+	}
+	return convertError(p.p64.ExecuteR2ROnN(in.r32(), out.r32()))
+}
@@ -0,0 +1,121 @@
+package fft
+
+import (
+	"errors"
+
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// Complex is the element type of complex arrays.
+type Complex interface {
+	complex64 | complex128
+}
+
+// Float is the element type of real arrays.
+type Float interface {
+	float32 | float64
+}
+
+// The direction, planner flags and real-to-real kinds are shared by both
+// precisions; see package fftw for their documentation.
+type (
+	Direction = fftw.Direction
+	Flag      = fftw.Flag
+	Kind      = fftw.Kind
+)
+
+const (
+	Forward  = fftw.Forward
+	Backward = fftw.Backward
+)
+
+const (
+	Estimate       = fftw.Estimate
+	Measure        = fftw.Measure
+	Patient        = fftw.Patient
+	Exhaustive     = fftw.Exhaustive
+	WisdomOnly     = fftw.WisdomOnly
+	DestroyInput   = fftw.DestroyInput
+	PreserveInput  = fftw.PreserveInput
+	Unaligned      = fftw.Unaligned
+	ConserveMemory = fftw.ConserveMemory
+)
+
+const (
+	R2HC    = fftw.R2HC
+	HC2R    = fftw.HC2R
+	DHT     = fftw.DHT
+	REDFT00 = fftw.REDFT00
+	REDFT01 = fftw.REDFT01
+	REDFT10 = fftw.REDFT10
+	REDFT11 = fftw.REDFT11
+	RODFT00 = fftw.RODFT00
+	RODFT01 = fftw.RODFT01
+	RODFT10 = fftw.RODFT10
+	RODFT11 = fftw.RODFT11
+)
+
+// The errors are those of package fftw; errors from fftw32 are converted so
+// that errors.Is matches them as well.
+//
+//nolint:gochecknoglobals
+var (
+	ErrDimensionsMismatch = fftw.ErrDimensionsMismatch
+	ErrJaggedArray        = fftw.ErrJaggedArray
+	ErrEmpty              = fftw.ErrEmpty
+	ErrNoPlan             = fftw.ErrNoPlan
+	ErrPlanKind           = fftw.ErrPlanKind
+	ErrInPlace            = fftw.ErrInPlace
+	ErrMisaligned         = fftw.ErrMisaligned
+)
+
+//nolint:gochecknoglobals
+var errors32 = []struct{ err32, err error }{
+	{fftw32.ErrDimensionsMismatch, ErrDimensionsMismatch},
+	{fftw32.ErrJaggedArray, ErrJaggedArray},
+	{fftw32.ErrEmpty, ErrEmpty},
+	{fftw32.ErrNoPlan, ErrNoPlan},
+	{fftw32.ErrPlanKind, ErrPlanKind},
+	{fftw32.ErrInPlace, ErrInPlace},
+	{fftw32.ErrMisaligned, ErrMisaligned},
+}
+
+// error32 is an error of package fftw32 that also matches the corresponding
+// error of this package.
+type error32 struct {
+	err, is error
+}
+
+func (e error32) Error() string   { return e.err.Error() }
+func (e error32) Unwrap() []error { return []error{e.err, e.is} }
+
+func convertError(err error) error {
+	for _, e := range errors32 {
+		if errors.Is(err, e.err32) {
+			return error32{err, e.err}
+		}
+	}
+	return err
+}
+
+// double reports whether T is complex128 rather than complex64.
+func double[T Complex]() bool {
+	var x T
+	_, ok := any(x).(complex128)
+	return ok
+}
+
+// doubleReal reports whether F is float64 rather than float32.
+func doubleReal[F Float]() bool {
+	var x F
+	_, ok := any(x).(float64)
+	return ok
+}
+
+// checkPrecision panics unless T and F have the same precision.
+func checkPrecision[T Complex, F Float]() {
+	if double[T]() != doubleReal[F]() {
+		panic("fft: complex and real arrays must have the same precision")
+	}
+}
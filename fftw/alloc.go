@@ -0,0 +1,259 @@
+//go:build cgo && !purego
+
+package fftw
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// allocation is memory from fftw_alloc_complex or fftw_alloc_real, which FFTW
+// aligns for the widest SIMD instructions it uses.
+type allocation struct {
+	ptr unsafe.Pointer
+	// The number of plans using the memory, and whether its array has been
+	// freed or collected, so that it is released once neither uses it.
+	plans    int
+	released bool
+}
+
+// The allocations by data pointer, so that plans can find those of their arrays.
+//
+//nolint:gochecknoglobals
+var (
+	allocMu sync.Mutex
+	allocs  = map[unsafe.Pointer]*allocation{}
+)
+
+func allocComplex(n int) ([]complex128, *allocation) {
+	if n <= 0 {
+		return make([]complex128, n), nil
+	}
+	a := newAllocation(unsafe.Pointer(C.fftw_alloc_complex(C.size_t(n))))
+	elems := unsafe.Slice((*complex128)(a.ptr), n)
+	clear(elems)
+	return elems, a
+}
+
+func allocReal(n int) ([]float64, *allocation) {
+	if n <= 0 {
+		return make([]float64, n), nil
+	}
+	a := newAllocation(unsafe.Pointer(C.fftw_alloc_real(C.size_t(n))))
+	elems := unsafe.Slice((*float64)(a.ptr), n)
+	clear(elems)
+	return elems, a
+}
+
+func newAllocation(ptr unsafe.Pointer) *allocation {
+	if ptr == nil {
+		panic("fftw: out of memory")
+	}
+	a := &allocation{ptr: ptr, plans: 0, released: false}
+	allocMu.Lock()
+	allocs[ptr] = a
+	allocMu.Unlock()
+	return a
+}
+
+// track frees a when the array owning it becomes unreachable, in case Free is
+// never called.
+func track[A any](array *A, a *allocation) *A {
+	if a != nil {
+		runtime.AddCleanup(array, (*allocation).release, a)
+	}
+	return array
+}
+
+// release is called when the array owning a is freed or collected.
+func (a *allocation) release() {
+	allocMu.Lock()
+	defer allocMu.Unlock()
+	a.released = true
+	a.free()
+}
+
+// free returns the memory to FFTW once neither the array nor a plan uses it.
+// The caller must hold allocMu.
+func (a *allocation) free() {
+	if !a.released || a.plans > 0 || a.ptr == nil {
+		return
+	}
+	delete(allocs, a.ptr)
+	C.fftw_free(a.ptr)
+	a.ptr = nil
+}
+
+// freeElems releases the memory of the array whose elements start at ptr, if it
+// was allocated by FFTW.
+func freeElems(ptr unsafe.Pointer) {
+	allocMu.Lock()
+	a := allocs[ptr]
+	allocMu.Unlock()
+	if a != nil {
+		a.release()
+	}
+}
+
+// hold pins the array at ptr for the plan p and, if FFTW allocated it, keeps
+// its memory until p is destroyed.
+func (p *Plan) hold(ptr unsafe.Pointer) {
+	p.pin.Pin(ptr)
+	allocMu.Lock()
+	if a := allocs[ptr]; a != nil {
+		a.plans++
+		p.allocs = append(p.allocs, a)
+	}
+	allocMu.Unlock()
+}
+
+// unhold undoes the calls to hold when p is destroyed.
+func (p *Plan) unhold() {
+	p.pin.Unpin()
+	allocMu.Lock()
+	for _, a := range p.allocs {
+		a.plans--
+		a.free()
+	}
+	allocMu.Unlock()
+	p.allocs = nil
+}
+
+// AlignmentOf returns the alignment of x as reported by fftw_alignment_of:
+// 0 if x is suitably aligned for SIMD, the misalignment otherwise.
+//
+// A plan can only be executed on arrays with the same alignment as the arrays it
+// was created for, unless it was created with Unaligned.
+func AlignmentOf[E complex128 | float64](x []E) int {
+	if len(x) == 0 {
+		return 0
+	}
+	return alignmentOf(unsafe.Pointer(unsafe.SliceData(x)))
+}
+
+// NewArrayAligned allocates a zeroed array with fftw_alloc_complex, aligned for
+// the SIMD instructions FFTW uses. Unlike those of NewArray, its elements live
+// outside the Go heap: they remain valid while the array is reachable or used by
+// a plan, so keep the array rather than only its Elems, and call Free when done.
+func NewArrayAligned(n int) *Array {
+	elems, a := allocComplex(n)
+	return track(&Array{elems}, a)
+}
+
+// 2D version of NewArrayAligned.
+func NewArray2Aligned(n0, n1 int) *Array2 {
+	elems, a := allocComplex(n0 * n1)
+	return track(&Array2{[...]int{n0, n1}, elems}, a)
+}
+
+// 3D version of NewArrayAligned.
+func NewArray3Aligned(n0, n1, n2 int) *Array3 {
+	elems, a := allocComplex(n0 * n1 * n2)
+	return track(&Array3{[...]int{n0, n1, n2}, elems}, a)
+}
+
+// N-dimensional version of NewArrayAligned.
+func NewArrayNAligned(n []int) *ArrayN {
+	elems, a := allocComplex(prod(n))
+	return track(&ArrayN{append([]int(nil), n...), elems}, a)
+}
+
+// NewRealArrayAligned is the version of NewArrayAligned for real arrays, using
+// fftw_alloc_real.
+func NewRealArrayAligned(n int) *RealArray {
+	elems, a := allocReal(n)
+	return track(&RealArray{elems}, a)
+}
+
+// 2D version of NewRealArrayAligned.
+func NewRealArray2Aligned(n0, n1 int) *RealArray2 {
+	elems, a := allocReal(n0 * n1)
+	return track(&RealArray2{[...]int{n0, n1}, elems, false}, a)
+}
+
+// 2D version of NewRealArrayAligned, with padded rows as NewRealArray2Padded.
+func NewRealArray2PaddedAligned(n0, n1 int) *RealArray2 {
+	elems, a := allocReal(n0 * rowLen(n1, true))
+	return track(&RealArray2{[...]int{n0, n1}, elems, true}, a)
+}
+
+// 3D version of NewRealArrayAligned.
+func NewRealArray3Aligned(n0, n1, n2 int) *RealArray3 {
+	elems, a := allocReal(n0 * n1 * n2)
+	return track(&RealArray3{[...]int{n0, n1, n2}, elems, false}, a)
+}
+
+// 3D version of NewRealArrayAligned, with a padded last dimension as NewRealArray3Padded.
+func NewRealArray3PaddedAligned(n0, n1, n2 int) *RealArray3 {
+	elems, a := allocReal(n0 * n1 * rowLen(n2, true))
+	return track(&RealArray3{[...]int{n0, n1, n2}, elems, true}, a)
+}
+
+// N-dimensional version of NewRealArrayAligned.
+func NewRealArrayNAligned(n []int) *RealArrayN {
+	elems, a := allocReal(prod(n))
+	return track(&RealArrayN{append([]int(nil), n...), elems, false}, a)
+}
+
+// N-dimensional version of NewRealArrayAligned, with a padded last dimension as
+// NewRealArrayNPadded.
+func NewRealArrayNPaddedAligned(n []int) *RealArrayN {
+	arr := &RealArrayN{append([]int(nil), n...), nil, true}
+	elems, a := allocReal(prod(arr.paddedDims()))
+	arr.Elems = elems
+	return track(arr, a)
+}
+
+// Free releases the memory of an array allocated by NewArrayAligned, once the
+// plans using it are destroyed, and clears Elems. For other arrays it only
+// clears Elems.
+func (a *Array) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 2D arrays.
+func (a *Array2) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 3D arrays.
+func (a *Array3) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for N-dimensional arrays.
+func (a *ArrayN) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for real arrays.
+func (a *RealArray) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 2D real arrays.
+func (a *RealArray2) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 3D real arrays.
+func (a *RealArray3) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for N-dimensional real arrays.
+func (a *RealArrayN) Free() {
+	freeElems(unsafe.Pointer(unsafe.SliceData(a.Elems)))
+	a.Elems = nil
+}
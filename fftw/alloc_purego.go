@@ -0,0 +1,137 @@
+//go:build !cgo || purego
+
+package fftw
+
+import "unsafe"
+
+// alignedFloats returns n zeroed float64s starting at a 16-byte boundary, the
+// alignment FFTW would give them.
+func alignedFloats(n int) []float64 {
+	if n <= 0 {
+		return make([]float64, n)
+	}
+	x := make([]float64, n+1)
+	if alignmentOf(unsafe.Pointer(&x[0])) != 0 {
+		x = x[1:]
+	}
+	return x[:n:n]
+}
+
+func allocComplex(n int) []complex128 {
+	if n <= 0 {
+		return make([]complex128, n)
+	}
+	return unsafe.Slice((*complex128)(unsafe.Pointer(&alignedFloats(2 * n)[0])), n)
+}
+
+// AlignmentOf returns the alignment of x as fftw_alignment_of would report it:
+// 0 if x starts at a 16-byte boundary, the misalignment otherwise.
+//
+// A plan can only be executed on arrays with the same alignment as the arrays it
+// was created for, unless it was created with Unaligned.
+func AlignmentOf[E complex128 | float64](x []E) int {
+	if len(x) == 0 {
+		return 0
+	}
+	return alignmentOf(unsafe.Pointer(unsafe.SliceData(x)))
+}
+
+// NewArrayAligned allocates a zeroed array aligned as fftw_alloc_complex would.
+// The pure-Go backend allocates it on the Go heap.
+func NewArrayAligned(n int) *Array {
+	return &Array{allocComplex(n)}
+}
+
+// 2D version of NewArrayAligned.
+func NewArray2Aligned(n0, n1 int) *Array2 {
+	return &Array2{[...]int{n0, n1}, allocComplex(n0 * n1)}
+}
+
+// 3D version of NewArrayAligned.
+func NewArray3Aligned(n0, n1, n2 int) *Array3 {
+	return &Array3{[...]int{n0, n1, n2}, allocComplex(n0 * n1 * n2)}
+}
+
+// N-dimensional version of NewArrayAligned.
+func NewArrayNAligned(n []int) *ArrayN {
+	return &ArrayN{append([]int(nil), n...), allocComplex(prod(n))}
+}
+
+// NewRealArrayAligned is the version of NewArrayAligned for real arrays.
+func NewRealArrayAligned(n int) *RealArray {
+	return &RealArray{alignedFloats(n)}
+}
+
+// 2D version of NewRealArrayAligned.
+func NewRealArray2Aligned(n0, n1 int) *RealArray2 {
+	return &RealArray2{[...]int{n0, n1}, alignedFloats(n0 * n1), false}
+}
+
+// 2D version of NewRealArrayAligned, with padded rows as NewRealArray2Padded.
+func NewRealArray2PaddedAligned(n0, n1 int) *RealArray2 {
+	return &RealArray2{[...]int{n0, n1}, alignedFloats(n0 * rowLen(n1, true)), true}
+}
+
+// 3D version of NewRealArrayAligned.
+func NewRealArray3Aligned(n0, n1, n2 int) *RealArray3 {
+	return &RealArray3{[...]int{n0, n1, n2}, alignedFloats(n0 * n1 * n2), false}
+}
+
+// 3D version of NewRealArrayAligned, with a padded last dimension as NewRealArray3Padded.
+func NewRealArray3PaddedAligned(n0, n1, n2 int) *RealArray3 {
+	return &RealArray3{[...]int{n0, n1, n2}, alignedFloats(n0 * n1 * rowLen(n2, true)), true}
+}
+
+// N-dimensional version of NewRealArrayAligned.
+func NewRealArrayNAligned(n []int) *RealArrayN {
+	return &RealArrayN{append([]int(nil), n...), alignedFloats(prod(n)), false}
+}
+
+// N-dimensional version of NewRealArrayAligned, with a padded last dimension as
+// NewRealArrayNPadded.
+func NewRealArrayNPaddedAligned(n []int) *RealArrayN {
+	arr := &RealArrayN{append([]int(nil), n...), nil, true}
+	arr.Elems = alignedFloats(prod(arr.paddedDims()))
+	return arr
+}
+
+// Free clears Elems. The pure-Go backend leaves the memory to the garbage
+// collector.
+func (a *Array) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 2D arrays.
+func (a *Array2) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 3D arrays.
+func (a *Array3) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for N-dimensional arrays.
+func (a *ArrayN) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for real arrays.
+func (a *RealArray) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 2D real arrays.
+func (a *RealArray2) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for 3D real arrays.
+func (a *RealArray3) Free() {
+	a.Elems = nil
+}
+
+// Free is the version of Array.Free for N-dimensional real arrays.
+func (a *RealArrayN) Free() {
+	a.Elems = nil
+}
@@ -0,0 +1,81 @@
+package fftw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewArrayAligned(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	a := NewArrayAligned(n)
+	defer a.Free()
+
+	if a.Len() != n {
+		t.Fatalf("Len = %d, want %d", a.Len(), n)
+	}
+
+	if AlignmentOf(a.Elems) != 0 {
+		t.Fatalf("AlignmentOf = %d, want 0", AlignmentOf(a.Elems))
+	}
+
+	for i := range a.Elems {
+		a.Elems[i] = complex(math.Cos(float64(i)/n*math.Pi*2), 0)
+	}
+
+	peakVerifier(t, FFT(a).Elems)
+}
+
+func TestAlignmentOf(t *testing.T) {
+	t.Parallel()
+
+	x := NewRealArrayAligned(9)
+	defer x.Free()
+
+	if AlignmentOf(x.Elems) != 0 {
+		t.Fatalf("AlignmentOf = %d, want 0", AlignmentOf(x.Elems))
+	}
+
+	// One float64 further is never aligned for SIMD.
+	if AlignmentOf(x.Elems[1:]) == 0 {
+		t.Fatal("expected a misaligned slice")
+	}
+
+	if AlignmentOf([]complex128(nil)) != 0 {
+		t.Fatal("expected 0 for an empty slice")
+	}
+}
+
+func TestFreeKeepsPlannedArrays(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	in := NewRealArray2PaddedAligned(n0, n1)
+	out := NewArray2Aligned(n0, n1/2+1)
+
+	p := NewPlanR2C2(in, out, Estimate)
+	defer p.Destroy()
+
+	// The plan keeps the memory of in until it is destroyed.
+	elems := in.Elems
+	in.Free()
+
+	if in.Elems != nil {
+		t.Fatal("Free did not clear Elems")
+	}
+
+	elems[0] = 1
+	p.Execute()
+
+	for _, x := range out.Elems {
+		testAlmostEqual(t, real(x), 1)
+		testAlmostEqual(t, imag(x), 0)
+	}
+
+	// Freeing twice or freeing a Go array is harmless.
+	in.Free()
+	NewArray(4).Free()
+}
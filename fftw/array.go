@@ -1,14 +1,13 @@
 package fftw
 
-// #include <fftw3.h>
-import "C"
+import "unsafe"
 
 // Data for a 1D signal.
 type Array struct {
 	Elems []complex128
 }
 
-// Allocates memory using fftw_malloc.
+// Allocates memory with make; see NewArrayAligned for memory allocated by FFTW.
 func NewArray(n int) *Array {
 	elems := make([]complex128, n)
 	return &Array{elems}
@@ -30,6 +29,32 @@ func (a *Array) ptr() *complex128 {
 	return &a.Elems[0]
 }
 
+// Data for a real 1D signal.
+type RealArray struct {
+	Elems []float64
+}
+
+func NewRealArray(n int) *RealArray {
+	elems := make([]float64, n)
+	return &RealArray{elems}
+}
+
+func (a *RealArray) Len() int {
+	return len(a.Elems)
+}
+
+func (a *RealArray) At(i int) float64 {
+	return a.Elems[i]
+}
+
+func (a *RealArray) Set(i int, x float64) {
+	a.Elems[i] = x
+}
+
+func (a *RealArray) ptr() *float64 {
+	return &a.Elems[0]
+}
+
 // 2D version of Array.
 type Array2 struct {
 	N     [2]int
@@ -70,6 +95,73 @@ func (a *Array2) ptr() *complex128 {
 	return &a.Elems[0]
 }
 
+// 2D version of RealArray.
+//
+// If Padded is set, each row is followed by padding up to 2*(N[1]/2+1) elements,
+// which is the layout FFTW uses for in-place real-to-complex transforms.
+type RealArray2 struct {
+	N      [2]int
+	Elems  []float64
+	Padded bool
+}
+
+func NewRealArray2(n0, n1 int) *RealArray2 {
+	elems := make([]float64, n0*n1)
+	return &RealArray2{[...]int{n0, n1}, elems, false}
+}
+
+// NewRealArray2Padded allocates an n0 x n1 real array with padded rows.
+// Use Complex to obtain the n0 x (n1/2+1) complex array that shares its memory.
+func NewRealArray2Padded(n0, n1 int) *RealArray2 {
+	elems := make([]float64, n0*2*(n1/2+1))
+	return &RealArray2{[...]int{n0, n1}, elems, true}
+}
+
+func (a *RealArray2) Dims() (int, int) {
+	return a.N[0], a.N[1]
+}
+
+func (a *RealArray2) At(i0, i1 int) float64 {
+	return a.Elems[a.index(i0, i1)]
+}
+
+func (a *RealArray2) Set(i0, i1 int, x float64) {
+	a.Elems[a.index(i0, i1)] = x
+}
+
+// Slice returns the rows of a, excluding any padding.
+func (a *RealArray2) Slice() [][]float64 {
+	x := a.Elems
+	row := a.rowLen()
+	s := make([][]float64, a.N[0])
+	for i := range s {
+		s[i], x = x[:a.N[1]], x[row:]
+	}
+	return s
+}
+
+// Complex returns the n0 x (n1/2+1) complex array that shares the memory of the
+// padded array a, for use with in-place real transforms.
+func (a *RealArray2) Complex() *Array2 {
+	if !a.Padded {
+		panic("fftw: real array must be padded")
+	}
+	n := [...]int{a.N[0], a.N[1]/2 + 1}
+	return &Array2{n, complexView(a.Elems, n[0]*n[1])}
+}
+
+func (a *RealArray2) rowLen() int {
+	return rowLen(a.N[1], a.Padded)
+}
+
+func (a *RealArray2) index(i0, i1 int) int {
+	return i1 + a.rowLen()*i0
+}
+
+func (a *RealArray2) ptr() *float64 {
+	return &a.Elems[0]
+}
+
 // 3D version of Array.
 type Array3 struct {
 	N     [3]int
@@ -113,6 +205,75 @@ func (a *Array3) index(i0, i1, i2 int) int {
 	return i2 + a.N[2]*(i1+i0*a.N[1])
 }
 
+// 3D version of RealArray.
+//
+// If Padded is set, the last dimension is padded to 2*(N[2]/2+1) elements.
+type RealArray3 struct {
+	N      [3]int
+	Elems  []float64
+	Padded bool
+}
+
+func NewRealArray3(n0, n1, n2 int) *RealArray3 {
+	elems := make([]float64, n0*n1*n2)
+	return &RealArray3{[...]int{n0, n1, n2}, elems, false}
+}
+
+// NewRealArray3Padded allocates an n0 x n1 x n2 real array with a padded last dimension.
+// Use Complex to obtain the n0 x n1 x (n2/2+1) complex array that shares its memory.
+func NewRealArray3Padded(n0, n1, n2 int) *RealArray3 {
+	elems := make([]float64, n0*n1*2*(n2/2+1))
+	return &RealArray3{[...]int{n0, n1, n2}, elems, true}
+}
+
+func (a *RealArray3) Dims() (int, int, int) {
+	return a.N[0], a.N[1], a.N[2]
+}
+
+func (a *RealArray3) At(i0, i1, i2 int) float64 {
+	return a.Elems[a.index(i0, i1, i2)]
+}
+
+func (a *RealArray3) Set(i0, i1, i2 int, x float64) {
+	a.Elems[a.index(i0, i1, i2)] = x
+}
+
+// Slice returns the rows of a, excluding any padding.
+func (a *RealArray3) Slice() [][][]float64 {
+	x := a.Elems
+	row := a.rowLen()
+	s := make([][][]float64, a.N[0])
+	for i := range s {
+		s[i] = make([][]float64, a.N[1])
+		for j := range s[i] {
+			s[i][j], x = x[:a.N[2]], x[row:]
+		}
+	}
+	return s
+}
+
+// Complex returns the n0 x n1 x (n2/2+1) complex array that shares the memory of
+// the padded array a, for use with in-place real transforms.
+func (a *RealArray3) Complex() *Array3 {
+	if !a.Padded {
+		panic("fftw: real array must be padded")
+	}
+	n := [...]int{a.N[0], a.N[1], a.N[2]/2 + 1}
+	return &Array3{n, complexView(a.Elems, n[0]*n[1]*n[2])}
+}
+
+func (a *RealArray3) rowLen() int {
+	return rowLen(a.N[2], a.Padded)
+}
+
+func (a *RealArray3) ptr() *float64 {
+	return &a.Elems[0]
+}
+
+func (a *RealArray3) index(i0, i1, i2 int) int {
+	return i2 + a.rowLen()*(i1+i0*a.N[1])
+}
+
 // N-dimensional version of Array.
 type ArrayN struct {
 	N     []int
@@ -151,6 +312,91 @@ func (a *ArrayN) index(i []int) int {
 	return m
 }
 
+// N-dimensional version of RealArray.
+//
+// If Padded is set, the last dimension is padded to 2*(n/2+1) elements.
+type RealArrayN struct {
+	N      []int
+	Elems  []float64
+	Padded bool
+}
+
+func NewRealArrayN(n []int) *RealArrayN {
+	var a RealArrayN
+	a.Elems = make([]float64, prod(n))
+	a.N = make([]int, len(n))
+	copy(a.N, n)
+	return &a
+}
+
+// NewRealArrayNPadded allocates a real array with a padded last dimension.
+// Use Complex to obtain the complex array, with last dimension n/2+1, that shares its memory.
+func NewRealArrayNPadded(n []int) *RealArrayN {
+	var a RealArrayN
+	a.N = make([]int, len(n))
+	copy(a.N, n)
+	a.Padded = true
+	a.Elems = make([]float64, prod(a.paddedDims()))
+	return &a
+}
+
+func (a *RealArrayN) Dims() []int {
+	return a.N
+}
+
+func (a *RealArrayN) At(i []int) float64 {
+	return a.Elems[a.index(i)]
+}
+
+func (a *RealArrayN) Set(i []int, x float64) {
+	a.Elems[a.index(i)] = x
+}
+
+// Complex returns the complex array, with last dimension n/2+1, that shares the
+// memory of the padded array a, for use with in-place real transforms.
+func (a *RealArrayN) Complex() *ArrayN {
+	if !a.Padded {
+		panic("fftw: real array must be padded")
+	}
+	n := halfDims(a.N)
+	return &ArrayN{n, complexView(a.Elems, prod(n))}
+}
+
+func (a *RealArrayN) ptr() *float64 {
+	return &a.Elems[0]
+}
+
+func (a *RealArrayN) index(i []int) int {
+	n := a.paddedDims()
+	var m int
+	for d := range n {
+		m = m*n[d] + i[d]
+	}
+	return m
+}
+
+// paddedDims returns the dimensions of the memory layout of a.
+func (a *RealArrayN) paddedDims() []int {
+	n := make([]int, len(a.N))
+	copy(n, a.N)
+	if len(n) > 0 {
+		n[len(n)-1] = rowLen(n[len(n)-1], a.Padded)
+	}
+	return n
+}
+
+func equalDims(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func prod(x []int) int {
 	t := 1
 	for _, xi := range x {
@@ -158,3 +404,42 @@ func prod(x []int) int {
 	}
 	return t
 }
+
+// halfDims returns the dimensions of the Hermitian half-spectrum of a real array
+// with dimensions n.
+func halfDims(n []int) []int {
+	h := make([]int, len(n))
+	copy(h, n)
+	if len(h) > 0 {
+		h[len(h)-1] = h[len(h)-1]/2 + 1
+	}
+	return h
+}
+
+// rowLen returns the length of a row of n real elements, including padding.
+func rowLen(n int, padded bool) int {
+	if padded {
+		return 2 * (n/2 + 1)
+	}
+	return n
+}
+
+// realDims returns the memory dimensions of a real array with dimensions n.
+func realDims(n []int, padded bool) []int {
+	m := append([]int(nil), n...)
+	if len(m) > 0 {
+		m[len(m)-1] = rowLen(m[len(m)-1], padded)
+	}
+	return m
+}
+
+// complexView reinterprets the memory of x as n complex values.
+func complexView(x []float64, n int) []complex128 {
+	if n == 0 || len(x) == 0 {
+		return nil
+	}
+	if len(x) < 2*n {
+		panic("fftw: real array is too short")
+	}
+	return unsafe.Slice((*complex128)(unsafe.Pointer(&x[0])), n)
+}
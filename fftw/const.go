@@ -1,18 +1,132 @@
 package fftw
 
-// #include <fftw3.h>
-import "C"
+import (
+	"fmt"
+	"strings"
+)
 
+// Direction is the sign of the exponent of a transform. The values of the
+// constants are those of fftw3.h.
 type Direction int
 
 const (
-	Forward  = Direction(C.FFTW_FORWARD)
-	Backward = Direction(C.FFTW_BACKWARD)
+	Forward  = Direction(-1)
+	Backward = Direction(1)
 )
 
+// Flag holds the planner flags, which can be combined with |.
+//
+// The planning rigor is one of Estimate, Measure, Patient, Exhaustive and
+// WisdomOnly. Measure, Patient and Exhaustive time actual transforms while
+// planning and therefore overwrite the input and output arrays, so plan before
+// filling them in. Estimate and WisdomOnly leave the arrays alone.
 type Flag uint
 
 const (
-	Estimate = Flag(C.FFTW_ESTIMATE)
-	Measure  = Flag(C.FFTW_MEASURE)
+	// Estimate picks a plan with a heuristic, without running any transforms.
+	Estimate = Flag(1 << 6)
+	// Measure times several transforms to pick a fast plan. This is FFTW's default.
+	Measure = Flag(0)
+	// Patient considers more algorithms than Measure, taking longer to plan.
+	Patient = Flag(1 << 5)
+	// Exhaustive considers even more algorithms than Patient.
+	Exhaustive = Flag(1 << 3)
+	// WisdomOnly only creates a plan if wisdom for it is available.
+	WisdomOnly = Flag(1 << 21)
+
+	// DestroyInput allows an out-of-place plan to overwrite its input array
+	// when executed. It is the default for complex-to-real transforms.
+	DestroyInput = Flag(1 << 0)
+	// PreserveInput forbids an out-of-place plan from overwriting its input
+	// array when executed, which is not possible for multi-dimensional
+	// complex-to-real transforms.
+	PreserveInput = Flag(1 << 4)
+	// Unaligned makes no assumption on the alignment of the arrays, so that
+	// ExecuteOn accepts arrays of any alignment, at some cost in speed.
+	Unaligned = Flag(1 << 1)
+	// ConserveMemory prefers plans that use less memory.
+	ConserveMemory = Flag(1 << 2)
+)
+
+//nolint:gochecknoglobals
+var flagNames = []struct {
+	flag Flag
+	name string
+}{
+	{Estimate, "Estimate"},
+	{Patient, "Patient"},
+	{Exhaustive, "Exhaustive"},
+	{WisdomOnly, "WisdomOnly"},
+	{DestroyInput, "DestroyInput"},
+	{PreserveInput, "PreserveInput"},
+	{Unaligned, "Unaligned"},
+	{ConserveMemory, "ConserveMemory"},
+}
+
+// String returns the flags of f joined by |, for example "Patient|DestroyInput".
+// Measure is shown when no other planning rigor is set.
+func (f Flag) String() string {
+	var names []string
+	if f&(Estimate|Patient|Exhaustive|WisdomOnly) == 0 {
+		names = append(names, "Measure")
+	}
+	rest := f &^ threadsMask
+	for _, fn := range flagNames {
+		if rest&fn.flag != 0 {
+			names = append(names, fn.name)
+			rest &^= fn.flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint(rest)))
+	}
+	if n := f.threads(); n > 0 {
+		names = append(names, fmt.Sprintf("Threads(%d)", n))
+	}
+	return strings.Join(names, "|")
+}
+
+// Kind selects the transform computed by a real-to-real plan.
+//
+// The REDFT kinds are the discrete cosine transforms and the RODFT kinds the
+// discrete sine transforms; see the FFTW manual for their exact definitions.
+type Kind uint32
+
+const (
+	R2HC    = Kind(0)  // Real to halfcomplex DFT.
+	HC2R    = Kind(1)  // Halfcomplex to real DFT, the inverse of R2HC.
+	DHT     = Kind(2)  // Discrete Hartley transform.
+	REDFT00 = Kind(3)  // DCT-I.
+	REDFT01 = Kind(4)  // DCT-III, the inverse of DCT-II.
+	REDFT10 = Kind(5)  // DCT-II, "the" DCT.
+	REDFT11 = Kind(6)  // DCT-IV.
+	RODFT00 = Kind(7)  // DST-I.
+	RODFT01 = Kind(8)  // DST-III, the inverse of DST-II.
+	RODFT10 = Kind(9)  // DST-II.
+	RODFT11 = Kind(10) // DST-IV.
 )
+
+// logicalSize returns the length of the equivalent DFT of a real-to-real transform
+// of n elements, by which a forward and inverse transform scale their input.
+func (k Kind) logicalSize(n int) int {
+	switch k {
+	case REDFT00:
+		return 2 * (n - 1)
+	case RODFT00:
+		return 2 * (n + 1)
+	case REDFT01, REDFT10, REDFT11, RODFT01, RODFT10, RODFT11:
+		return 2 * n
+	default:
+		return n
+	}
+}
+
+// checkKind panics if kind is not a valid transform of n elements.
+func checkKind(kind Kind, n int) {
+	if kind > RODFT11 {
+		panic("fftw: unknown real-to-real kind")
+	}
+	if kind == REDFT00 && n < 2 {
+		panic("fftw: REDFT00 requires at least 2 elements")
+	}
+}
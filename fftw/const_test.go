@@ -0,0 +1,49 @@
+package fftw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlagString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		flag Flag
+		want string
+	}{
+		{Measure, "Measure"},
+		{Estimate, "Estimate"},
+		{Patient | DestroyInput, "Patient|DestroyInput"},
+		{Exhaustive | PreserveInput | Unaligned | ConserveMemory, "Exhaustive|PreserveInput|Unaligned|ConserveMemory"},
+		{WisdomOnly | Estimate, "Estimate|WisdomOnly"},
+		{Measure | Threads(3), "Measure|Threads(3)"},
+	} {
+		if got := tc.flag.String(); got != tc.want {
+			t.Errorf("%#x: expected %q, got %q", uint(tc.flag), tc.want, got)
+		}
+	}
+}
+
+func TestSetTimeLimit(t *testing.T) {
+	t.Parallel()
+
+	SetTimeLimit(10 * time.Millisecond)
+	defer SetTimeLimit(NoTimeLimit)
+
+	in, out := NewArray(256), NewArray(256)
+	p := NewPlan(in, out, Forward, Patient)
+	defer p.Destroy()
+
+	for i := range in.Elems {
+		in.Elems[i] = complex(float64(i%7), 0)
+	}
+
+	p.Execute()
+
+	want := FFT(in)
+	for i := range want.Elems {
+		testAlmostEqual(t, real(out.Elems[i]), real(want.Elems[i]))
+		testAlmostEqual(t, imag(out.Elems[i]), imag(want.Elems[i]))
+	}
+}
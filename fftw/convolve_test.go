@@ -0,0 +1,227 @@
+package fftw
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// directConvolve computes the full convolution of the row-major arrays a and b
+// with dimensions aDims and bDims, or their correlation, by its definition.
+func directConvolve(aDims, bDims []int, a, b []complex128, correlate bool) ([]int, []complex128) {
+	full := make([]int, len(aDims))
+	for i := range full {
+		full[i] = aDims[i] + bDims[i] - 1
+	}
+	out := make([]complex128, prod(full))
+	for i, x := range a {
+		for j, y := range b {
+			// The index of the term is the sum of those of x and y, with y
+			// reversed for a correlation.
+			k, ri, rj, stride := 0, i, j, 1
+			for d := len(full) - 1; d >= 0; d-- {
+				ii, jj := ri%aDims[d], rj%bDims[d]
+				ri, rj = ri/aDims[d], rj/bDims[d]
+				if correlate {
+					jj = bDims[d] - 1 - jj
+				}
+				k += (ii + jj) * stride
+				stride *= full[d]
+			}
+			if correlate {
+				y = cmplx.Conj(y)
+			}
+			out[k] += x * y
+		}
+	}
+	return full, out
+}
+
+// crop returns the part of the full convolution that mode selects, as defined
+// by scipy.signal.
+func crop(aDims, bDims, full []int, x []complex128, mode Mode) ([]int, []complex128) {
+	dims := make([]int, len(full))
+	start := make([]int, len(full))
+	for i := range full {
+		switch mode {
+		case ModeFull:
+			dims[i] = full[i]
+		case ModeSame:
+			dims[i] = aDims[i]
+		case ModeValid:
+			dims[i] = max(aDims[i], bDims[i]) - min(aDims[i], bDims[i]) + 1
+		}
+		start[i] = (full[i] - dims[i]) / 2
+	}
+	out := make([]complex128, prod(dims))
+	embed(dims, full, start, false, func(i, j int) { out[i] = x[j] })
+	return dims, out
+}
+
+func testSignal(n, seed int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i*i+seed)), math.Cos(float64(3*i+seed)))
+	}
+	return x
+}
+
+func TestConvolve(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b []int
+	}{
+		{[]int{1}, []int{1}},
+		{[]int{8}, []int{3}},
+		{[]int{5}, []int{12}},
+		{[]int{17}, []int{17}},
+		{[]int{4, 5}, []int{3, 2}},
+		{[]int{3, 6}, []int{3, 4}},
+		{[]int{2, 3, 4}, []int{2, 2, 3}},
+		{[]int{3, 2, 2, 3}, []int{2, 2, 1, 2}},
+	} {
+		a, b := testSignal(prod(tc.a), 0), testSignal(prod(tc.b), 1)
+		ra, rb := make([]float64, len(a)), make([]float64, len(b))
+		for i := range a {
+			ra[i] = real(a[i])
+		}
+		for i := range b {
+			rb[i] = real(b[i])
+		}
+
+		for _, mode := range []Mode{ModeFull, ModeSame, ModeValid} {
+			for _, correlate := range []bool{false, true} {
+				full, x := directConvolve(tc.a, tc.b, a, b, correlate)
+				wantDims, want := crop(tc.a, tc.b, full, x, mode)
+				full, x = directConvolve(tc.a, tc.b, complexFromReal(ra), complexFromReal(rb), correlate)
+				_, wantReal := crop(tc.a, tc.b, full, x, mode)
+
+				dims, got, gotReal := convolveByRank(tc.a, tc.b, a, b, ra, rb, mode, correlate)
+				if !equalDims(dims, wantDims) {
+					t.Fatalf("%v %v %v: dimensions %v, want %v", tc.a, tc.b, mode, dims, wantDims)
+				}
+				for i := range want {
+					if cmplx.Abs(got[i]-want[i]) > 1e-10*float64(len(a)+len(b)) ||
+						math.Abs(gotReal[i]-real(wantReal[i])) > 1e-10*float64(len(a)+len(b)) {
+						t.Fatalf("%v %v %v correlate=%v: element %d is %v and %v, want %v and %v",
+							tc.a, tc.b, mode, correlate, i, got[i], gotReal[i], want[i], real(wantReal[i]))
+					}
+				}
+			}
+		}
+	}
+}
+
+func complexFromReal(x []float64) []complex128 {
+	z := make([]complex128, len(x))
+	for i := range x {
+		z[i] = complex(x[i], 0)
+	}
+	return z
+}
+
+// convolveByRank calls the convolution functions for the rank of aDims.
+func convolveByRank(aDims, bDims []int, a, b []complex128, ra, rb []float64,
+	mode Mode, correlate bool,
+) ([]int, []complex128, []float64) {
+	switch len(aDims) {
+	case 1:
+		x, y := &Array{a}, &Array{b}
+		rx, ry := &RealArray{ra}, &RealArray{rb}
+		if correlate {
+			return []int{Correlate(x, y, mode).Len()}, Correlate(x, y, mode).Elems, CorrelateReal(rx, ry, mode).Elems
+		}
+		return []int{Convolve(x, y, mode).Len()}, Convolve(x, y, mode).Elems, ConvolveReal(rx, ry, mode).Elems
+	case 2:
+		x, y := &Array2{[2]int(aDims), a}, &Array2{[2]int(bDims), b}
+		rx, ry := &RealArray2{[2]int(aDims), ra, false}, &RealArray2{[2]int(bDims), rb, false}
+		if correlate {
+			z := Correlate2(x, y, mode)
+			return z.N[:], z.Elems, CorrelateReal2(rx, ry, mode).Elems
+		}
+		z := Convolve2(x, y, mode)
+		return z.N[:], z.Elems, ConvolveReal2(rx, ry, mode).Elems
+	case 3:
+		x, y := &Array3{[3]int(aDims), a}, &Array3{[3]int(bDims), b}
+		rx, ry := &RealArray3{[3]int(aDims), ra, false}, &RealArray3{[3]int(bDims), rb, false}
+		if correlate {
+			z := Correlate3(x, y, mode)
+			return z.N[:], z.Elems, CorrelateReal3(rx, ry, mode).Elems
+		}
+		z := Convolve3(x, y, mode)
+		return z.N[:], z.Elems, ConvolveReal3(rx, ry, mode).Elems
+	}
+	x, y := &ArrayN{aDims, a}, &ArrayN{bDims, b}
+	rx, ry := &RealArrayN{aDims, ra, false}, &RealArrayN{bDims, rb, false}
+	if correlate {
+		z := CorrelateN(x, y, mode)
+		return z.N, z.Elems, CorrelateRealN(rx, ry, mode).Elems
+	}
+	z := ConvolveN(x, y, mode)
+	return z.N, z.Elems, ConvolveRealN(rx, ry, mode).Elems
+}
+
+func TestConvolveRealDynamicRange(t *testing.T) {
+	t.Parallel()
+
+	// The error must scale with the sizes of a and b, not of the larger one.
+	const n = 64
+	a, b := make([]float64, n), make([]float64, n)
+	var sumA, sumB float64
+	for i := range n {
+		a[i] = 1e6 * math.Sin(float64(i*i))
+		b[i] = 1e-6 * math.Cos(float64(3*i))
+		sumA += math.Abs(a[i])
+		sumB += math.Abs(b[i])
+	}
+	for _, correlate := range []bool{false, true} {
+		_, want := directConvolve([]int{n}, []int{n}, complexFromReal(a), complexFromReal(b), correlate)
+		got := ConvolveReal(&RealArray{a}, &RealArray{b}, ModeFull).Elems
+		if correlate {
+			got = CorrelateReal(&RealArray{a}, &RealArray{b}, ModeFull).Elems
+		}
+		for i := range want {
+			if math.Abs(got[i]-real(want[i])) > 1e-13*sumA*sumB {
+				t.Fatalf("correlate=%v: element %d is %v, want %v", correlate, i, got[i], real(want[i]))
+			}
+		}
+	}
+}
+
+// TestConvolvePlanReuse is not parallel, so that no other test uses the cache of
+// the helpers meanwhile.
+func TestConvolvePlanReuse(t *testing.T) {
+	a, b := NewRealArray2(30, 40), NewRealArray2(5, 5)
+	x, y := NewArray2(30, 40), NewArray2(5, 5)
+	ConvolveReal2(a, b, ModeSame)
+	Convolve2(x, y, ModeSame)
+	before := DefaultPlanCache().Stats()
+	ConvolveReal2(a, b, ModeFull)
+	Convolve2(x, y, ModeFull)
+
+	// 34 x 44 is padded to 35 x 45 in every mode.
+	if s := DefaultPlanCache().Stats(); s.Misses != before.Misses {
+		t.Fatalf("Stats = %+v after %+v, want no new plans", s, before)
+	}
+}
+
+func TestFastSize(t *testing.T) {
+	t.Parallel()
+
+	for n, want := range map[int]int{0: 1, 1: 1, 11: 12, 13: 14, 17: 18, 97: 98, 1000: 1000, 1031: 1050} {
+		if got := FastSize(n); got != want {
+			t.Fatalf("FastSize(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestConvolvePanics(t *testing.T) {
+	t.Parallel()
+
+	expectPanic(t, "empty", func() { Convolve(NewArray(0), NewArray(3), ModeFull) })
+	expectPanic(t, "rank mismatch", func() { ConvolveN(NewArrayN([]int{2, 2}), NewArrayN([]int{2}), ModeFull) })
+	expectPanic(t, "no valid part", func() { Convolve2(NewArray2(2, 5), NewArray2(3, 3), ModeValid) })
+	expectPanic(t, "invalid mode", func() { Correlate(NewArray(3), NewArray(3), Mode(3)) })
+	expectPanic(t, "padded", func() { ConvolveReal2(NewRealArray2Padded(2, 2), NewRealArray2(2, 2), ModeFull) })
+}
@@ -1,14 +1,6 @@
 package fftw
 
-import (
-	"errors"
-	"fmt"
-)
-
-var (
-	ErrDimensionsMismatch = errors.New("dimensions mismatch")
-	ErrJaggedArray        = errors.New("jagged array")
-)
+import "fmt"
 
 func CopySlice2(dst *Array2, src [][]complex128) error {
 	srcDim0, srcDim1, err := dims2(src)
@@ -0,0 +1,53 @@
+//go:build cgo && !purego
+
+package fftw
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"fmt"
+	"io"
+)
+
+// Flops returns the exact number of floating-point additions, multiplications
+// and fused multiply-adds performed by one execution of p. An fma counts as
+// one operation, not as an addition and a multiplication.
+// A destroyed plan reports zero operations.
+func (p *Plan) Flops() (add, mul, fma float64) {
+	if p.fftwP == nil {
+		return 0, 0, 0
+	}
+	var a, m, f C.double
+	C.fftw_flops(p.fftwP, &a, &m, &f)
+	return float64(a), float64(m), float64(f)
+}
+
+// Cost returns the cost of p as measured by the planner, in arbitrary units.
+// It is 0 for plans created with Estimate or from wisdom, which were not
+// measured.
+func (p *Plan) Cost() float64 {
+	if p.fftwP == nil {
+		return 0
+	}
+	return float64(C.fftw_cost(p.fftwP))
+}
+
+// EstimateCost returns the planner's heuristic estimate of the cost of p,
+// in the same units as Cost. It is also available for Estimate plans.
+func (p *Plan) EstimateCost() float64 {
+	if p.fftwP == nil {
+		return 0
+	}
+	return float64(C.fftw_estimate_cost(p.fftwP))
+}
+
+// WriteTo writes the textual description of p that fftw_fprint_plan would
+// print to w. It implements io.WriterTo.
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	if p.fftwP == nil {
+		return 0, fmt.Errorf("%w: plan has been destroyed", ErrPlanKind)
+	}
+	n, err := io.WriteString(w, p.String())
+	return int64(n), err
+}
@@ -0,0 +1,138 @@
+//go:build !cgo || purego
+
+package fftw
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestPureGoDFTSizes(t *testing.T) {
+	t.Parallel()
+
+	// Powers of the radices, their products, and primes beyond maxRadix for
+	// Bluestein's algorithm.
+	sizes := []int{97, 121, 169, 289, 360, 1000, 1009}
+	for n := 1; n <= 40; n++ {
+		sizes = append(sizes, n)
+	}
+
+	for _, n := range sizes {
+		src := NewArray(n)
+		for i := range src.Elems {
+			src.Elems[i] = complex(math.Sin(float64(i*i)), math.Cos(float64(3*i)))
+		}
+
+		for _, dir := range []Direction{Forward, Backward} {
+			dst := NewArray(n)
+			NewPlan(src, dst, dir, Estimate).Execute().Destroy()
+
+			for k := range n {
+				var want complex128
+				for j := range n {
+					want += src.Elems[j] * cmplx.Rect(1, float64(dir)*2*math.Pi*float64(j*k%n)/float64(n))
+				}
+
+				if cmplx.Abs(dst.Elems[k]-want) > 1e-9*float64(n) {
+					t.Fatalf("n=%d dir=%d: X[%d] = %v, want %v", n, dir, k, dst.Elems[k], want)
+				}
+			}
+		}
+	}
+}
+
+func TestPureGoR2RKinds(t *testing.T) {
+	t.Parallel()
+
+	for kind := R2HC; kind <= RODFT11; kind++ {
+		for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 17} {
+			if kind == REDFT00 && n < 2 {
+				continue
+			}
+
+			src, dst := NewRealArray(n), NewRealArray(n)
+			for i := range src.Elems {
+				src.Elems[i] = math.Sin(float64(i*i)) + 0.5
+			}
+
+			NewPlanR2R(src, dst, kind, Estimate).Execute().Destroy()
+
+			want := directR2R(kind, src.Elems)
+			for k := range n {
+				if math.Abs(dst.Elems[k]-want[k]) > 1e-9*float64(n) {
+					t.Fatalf("%v n=%d: Y[%d] = %v, want %v", kind, n, k, dst.Elems[k], want[k])
+				}
+			}
+		}
+	}
+}
+
+// directR2R computes the real-to-real transform of x by the definitions in the
+// FFTW manual.
+func directR2R(kind Kind, x []float64) []float64 {
+	n := len(x)
+	fn := float64(n)
+	y := make([]float64, n)
+	for k := range n {
+		fk := float64(k)
+		for j := range n {
+			fj := float64(j)
+			switch kind {
+			case R2HC:
+				if k <= n/2 {
+					y[k] += x[j] * math.Cos(2*math.Pi*fj*fk/fn)
+				} else {
+					y[k] -= x[j] * math.Sin(2*math.Pi*fj*float64(n-k)/fn)
+				}
+			case HC2R:
+				y[k] += hc2rTerm(x, j, k)
+			case DHT:
+				y[k] += x[j] * (math.Cos(2*math.Pi*fj*fk/fn) + math.Sin(2*math.Pi*fj*fk/fn))
+			case REDFT00:
+				c := 2.0
+				if j == 0 || j == n-1 {
+					c = 1
+				}
+				y[k] += c * x[j] * math.Cos(math.Pi*fj*fk/(fn-1))
+			case REDFT10:
+				y[k] += 2 * x[j] * math.Cos(math.Pi*(fj+0.5)*fk/fn)
+			case REDFT01:
+				c := 2.0
+				if j == 0 {
+					c = 1
+				}
+				y[k] += c * x[j] * math.Cos(math.Pi*fj*(fk+0.5)/fn)
+			case REDFT11:
+				y[k] += 2 * x[j] * math.Cos(math.Pi*(fj+0.5)*(fk+0.5)/fn)
+			case RODFT00:
+				y[k] += 2 * x[j] * math.Sin(math.Pi*(fj+1)*(fk+1)/(fn+1))
+			case RODFT10:
+				y[k] += 2 * x[j] * math.Sin(math.Pi*(fj+0.5)*(fk+1)/fn)
+			case RODFT01:
+				c := 2.0
+				if j == n-1 {
+					c = 1
+				}
+				y[k] += c * x[j] * math.Sin(math.Pi*(fj+1)*(fk+0.5)/fn)
+			case RODFT11:
+				y[k] += 2 * x[j] * math.Sin(math.Pi*(fj+0.5)*(fk+0.5)/fn)
+			}
+		}
+	}
+	return y
+}
+
+// hc2rTerm returns the term of the halfcomplex element j in output k of HC2R.
+func hc2rTerm(x []float64, j, k int) float64 {
+	n := len(x)
+	theta := 2 * math.Pi * float64(j*k%n) / float64(n)
+	switch {
+	case j == 0, 2*j == n:
+		return x[j] * math.Cos(theta)
+	case j <= n/2:
+		return 2 * x[j] * math.Cos(theta)
+	default:
+		return -2 * x[j] * math.Sin(2*math.Pi*float64((n-j)*k%n)/float64(n))
+	}
+}
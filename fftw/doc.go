@@ -12,6 +12,18 @@ Provides simple functions to compute a transform without destroying existing dat
 
 Beware that scaling is the same as in FFTW, so that computing forward and then inverse
 transforms scales the original input by the length of the sequence.
+The Norm variants of the functions scale the result with the semantics of numpy,
+so that the round trip reproduces the input:
+
+	xhat := fftw.FFTNorm(x, fftw.NormBackward)
+	x = fftw.IFFTNorm(xhat, fftw.NormBackward)
+
+Plan.ExecuteNormalized does the same for plans.
+
+The With variants of the functions take options for the planner flags, threads,
+normalization and plan cache of a single call:
+
+	xhat := fftw.FFTWith(x, fftw.WithFlag(fftw.Measure), fftw.WithNorm(fftw.NormOrtho))
 
 Use fftw.XxxTo() to do in-place operations
 
@@ -37,5 +49,11 @@ It's possible to use FFTW with memory not allocated by fftw.NewArrayX():
 
 	x := make([]complex128, 100)
 	xhat := fftw.FFT(&fftw.Array{x})
+
+When built without cgo, or with the purego build tag, the package uses a pure-Go
+backend with mixed-radix and Bluestein algorithms instead of FFTW. It provides
+the arrays, the basic, real, real-to-real and batched plans and the FFT helpers,
+and it is slower than FFTW. The guru and split plans fail with ErrNoPlan, and
+the wisdom functions with ErrWisdom.
 */
 package fftw
@@ -0,0 +1,15 @@
+package fftw
+
+import "errors"
+
+var (
+	ErrDimensionsMismatch = errors.New("dimensions mismatch")
+	ErrJaggedArray        = errors.New("jagged array")
+	ErrEmpty              = errors.New("empty array")
+	ErrNoPlan             = errors.New("FFTW could not create a plan")
+	ErrPlanKind           = errors.New("plan does not support this kind of execution")
+	ErrInPlace            = errors.New("in-place and out-of-place arrays are not interchangeable")
+	ErrMisaligned         = errors.New("array alignment differs from the planned arrays")
+	ErrWisdom             = errors.New("invalid or incompatible wisdom")
+	ErrThreads            = errors.New("threads are not available")
+)
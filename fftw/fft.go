@@ -4,7 +4,7 @@ package fftw
 // It allocates memory in which to return the result.
 func FFT(src *Array) *Array {
 	dst := NewArray(src.Len())
-	fftDir(dst, src, Forward)
+	fftDir(dst, src, Forward, unscaled)
 
 	return dst
 }
@@ -13,31 +13,28 @@ func FFT(src *Array) *Array {
 // It allocates memory in which to return the result.
 func IFFT(src *Array) *Array {
 	dst := NewArray(src.Len())
-	fftDir(dst, src, Backward)
+	fftDir(dst, src, Backward, unscaled)
 
 	return dst
 }
 
 // FFTTo computes the Fourier transform of src
 // and returns the result in dst.
-func FFTTo(dst, src *Array) { fftDir(dst, src, Forward) }
+func FFTTo(dst, src *Array) { fftDir(dst, src, Forward, unscaled) }
 
 // IFFTTo computes the inverse Fourier transform of src
 // and returns the result in dst.
-func IFFTTo(dst, src *Array) { fftDir(dst, src, Backward) }
+func IFFTTo(dst, src *Array) { fftDir(dst, src, Backward, unscaled) }
 
-func fftDir(dst, src *Array, dir Direction) {
-	p := NewPlan(src, dst, dir, Estimate)
-	defer p.Destroy()
-
-	p.Execute()
+func fftDir(dst, src *Array, dir Direction, norm Norm) {
+	defaultCache.transform([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, dir, Estimate, norm)
 }
 
 // FFT2 computes the Fourier transform of src.
 // It allocates memory in which to return the result.
 func FFT2(src *Array2) *Array2 {
 	dst := NewArray2(src.Dims())
-	fft2Dir(dst, src, Forward)
+	fft2Dir(dst, src, Forward, unscaled)
 
 	return dst
 }
@@ -46,31 +43,28 @@ func FFT2(src *Array2) *Array2 {
 // It allocates memory in which to return the result.
 func IFFT2(src *Array2) *Array2 {
 	dst := NewArray2(src.Dims())
-	fft2Dir(dst, src, Backward)
+	fft2Dir(dst, src, Backward, unscaled)
 
 	return dst
 }
 
 // FFT2To computes the Fourier transform of src
 // and returns the result in dst.
-func FFT2To(dst, src *Array2) { fft2Dir(dst, src, Forward) }
+func FFT2To(dst, src *Array2) { fft2Dir(dst, src, Forward, unscaled) }
 
 // IFFT2To computes the inverse Fourier transform of src
 // and returns the result in dst.
-func IFFT2To(dst, src *Array2) { fft2Dir(dst, src, Backward) }
-
-func fft2Dir(dst, src *Array2, dir Direction) {
-	p := NewPlan2(src, dst, dir, Estimate)
-	defer p.Destroy()
+func IFFT2To(dst, src *Array2) { fft2Dir(dst, src, Backward, unscaled) }
 
-	p.Execute()
+func fft2Dir(dst, src *Array2, dir Direction, norm Norm) {
+	defaultCache.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, dir, Estimate, norm)
 }
 
 // FFT3 computes the Fourier transform of src.
 // It allocates memory in which to return the result.
 func FFT3(src *Array3) *Array3 {
 	dst := NewArray3(src.Dims())
-	fft3Dir(dst, src, Forward)
+	fft3Dir(dst, src, Forward, unscaled)
 
 	return dst
 }
@@ -79,31 +73,28 @@ func FFT3(src *Array3) *Array3 {
 // It allocates memory in which to return the result.
 func IFFT3(src *Array3) *Array3 {
 	dst := NewArray3(src.Dims())
-	fft3Dir(dst, src, Backward)
+	fft3Dir(dst, src, Backward, unscaled)
 
 	return dst
 }
 
 // FFT3To computes the Fourier transform of src
 // and returns the result in dst.
-func FFT3To(dst, src *Array3) { fft3Dir(dst, src, Forward) }
+func FFT3To(dst, src *Array3) { fft3Dir(dst, src, Forward, unscaled) }
 
 // IFFT3To computes the inverse Fourier transform of src
 // and returns the result in dst.
-func IFFT3To(dst, src *Array3) { fft3Dir(dst, src, Backward) }
-
-func fft3Dir(dst, src *Array3, dir Direction) {
-	p := NewPlan3(src, dst, dir, Estimate)
-	defer p.Destroy()
+func IFFT3To(dst, src *Array3) { fft3Dir(dst, src, Backward, unscaled) }
 
-	p.Execute()
+func fft3Dir(dst, src *Array3, dir Direction, norm Norm) {
+	defaultCache.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, dir, Estimate, norm)
 }
 
 // FFTN computes the Fourier transform of src.
 // It allocates memory in which to return the result.
 func FFTN(src *ArrayN) *ArrayN {
 	dst := NewArrayN(src.Dims())
-	fftNDir(dst, src, Forward)
+	fftNDir(dst, src, Forward, unscaled)
 
 	return dst
 }
@@ -112,22 +103,274 @@ func FFTN(src *ArrayN) *ArrayN {
 // It allocates memory in which to return the result.
 func IFFTN(src *ArrayN) *ArrayN {
 	dst := NewArrayN(src.Dims())
-	fftNDir(dst, src, Backward)
+	fftNDir(dst, src, Backward, unscaled)
 
 	return dst
 }
 
 // FFTNTo computes the Fourier transform of src
 // and returns the result in dst.
-func FFTNTo(dst, src *ArrayN) { fftNDir(dst, src, Forward) }
+func FFTNTo(dst, src *ArrayN) { fftNDir(dst, src, Forward, unscaled) }
 
 // IFFTNTo computes the inverse Fourier transform of src
 // and returns the result in dst.
-func IFFTNTo(dst, src *ArrayN) { fftNDir(dst, src, Backward) }
+func IFFTNTo(dst, src *ArrayN) { fftNDir(dst, src, Backward, unscaled) }
+
+func fftNDir(dst, src *ArrayN, dir Direction, norm Norm) {
+	defaultCache.transform(dst.N, src.N, dst.Elems, src.Elems, dir, Estimate, norm)
+}
+
+// RFFT computes the Fourier transform of the real signal src.
+// It allocates memory in which to return the n/2+1 non-negative frequency terms.
+func RFFT(src *RealArray) *Array {
+	dst := NewArray(src.Len()/2 + 1)
+	RFFTTo(dst, src)
+
+	return dst
+}
+
+// IRFFT computes the inverse Fourier transform of the Hermitian half-spectrum src,
+// which must hold n/2+1 elements, and returns the real signal of length n.
+// It allocates memory in which to return the result.
+func IRFFT(src *Array, n int) *RealArray {
+	dst := NewRealArray(n)
+	IRFFTTo(dst, src)
+
+	return dst
+}
+
+// RFFTTo computes the Fourier transform of the real signal src
+// and returns the n/2+1 non-negative frequency terms in dst.
+func RFFTTo(dst *Array, src *RealArray) { rfftTo(dst, src, unscaled) }
+
+func rfftTo(dst *Array, src *RealArray, norm Norm) {
+	p := NewPlanR2C(src, dst, Estimate)
+	defer p.Destroy()
+
+	p.ExecuteNormalized(norm)
+}
+
+// IRFFTTo computes the inverse Fourier transform of the Hermitian half-spectrum src
+// and returns the real result in dst.
+// Unlike executing a plan from NewPlanC2R, it leaves src intact.
+func IRFFTTo(dst *RealArray, src *Array) { irfftTo(dst, src, unscaled) }
+
+func irfftTo(dst *RealArray, src *Array, norm Norm) {
+	tmp := NewArray(src.Len())
+
+	p := NewPlanC2R(tmp, dst, Estimate)
+	defer p.Destroy()
+
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm)
+}
+
+// RFFT2 computes the Fourier transform of the n0 x n1 real array src.
+// It allocates memory in which to return the n0 x (n1/2+1) half-spectrum.
+func RFFT2(src *RealArray2) *Array2 {
+	n0, n1 := src.Dims()
+	dst := NewArray2(n0, n1/2+1)
+	RFFT2To(dst, src)
+
+	return dst
+}
+
+// IRFFT2 computes the inverse Fourier transform of the n0 x (n1/2+1) half-spectrum src
+// and returns the n0 x n1 real result.
+// It allocates memory in which to return the result.
+func IRFFT2(src *Array2, n1 int) *RealArray2 {
+	n0, _ := src.Dims()
+	dst := NewRealArray2(n0, n1)
+	IRFFT2To(dst, src)
+
+	return dst
+}
+
+// RFFT2To computes the Fourier transform of the real array src
+// and returns the half-spectrum in dst.
+func RFFT2To(dst *Array2, src *RealArray2) { rfft2To(dst, src, unscaled) }
+
+func rfft2To(dst *Array2, src *RealArray2, norm Norm) {
+	p := NewPlanR2C2(src, dst, Estimate)
+	defer p.Destroy()
+
+	p.ExecuteNormalized(norm)
+}
+
+// IRFFT2To computes the inverse Fourier transform of the half-spectrum src
+// and returns the real result in dst, leaving src intact.
+func IRFFT2To(dst *RealArray2, src *Array2) { irfft2To(dst, src, unscaled) }
+
+func irfft2To(dst *RealArray2, src *Array2, norm Norm) {
+	tmp := NewArray2(src.Dims())
+
+	p := NewPlanC2R2(tmp, dst, Estimate)
+	defer p.Destroy()
+
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm)
+}
+
+// RFFT3 computes the Fourier transform of the n0 x n1 x n2 real array src.
+// It allocates memory in which to return the n0 x n1 x (n2/2+1) half-spectrum.
+func RFFT3(src *RealArray3) *Array3 {
+	n0, n1, n2 := src.Dims()
+	dst := NewArray3(n0, n1, n2/2+1)
+	RFFT3To(dst, src)
+
+	return dst
+}
+
+// IRFFT3 computes the inverse Fourier transform of the n0 x n1 x (n2/2+1) half-spectrum src
+// and returns the n0 x n1 x n2 real result.
+// It allocates memory in which to return the result.
+func IRFFT3(src *Array3, n2 int) *RealArray3 {
+	n0, n1, _ := src.Dims()
+	dst := NewRealArray3(n0, n1, n2)
+	IRFFT3To(dst, src)
+
+	return dst
+}
+
+// RFFT3To computes the Fourier transform of the real array src
+// and returns the half-spectrum in dst.
+func RFFT3To(dst *Array3, src *RealArray3) { rfft3To(dst, src, unscaled) }
+
+func rfft3To(dst *Array3, src *RealArray3, norm Norm) {
+	p := NewPlanR2C3(src, dst, Estimate)
+	defer p.Destroy()
+
+	p.ExecuteNormalized(norm)
+}
+
+// IRFFT3To computes the inverse Fourier transform of the half-spectrum src
+// and returns the real result in dst, leaving src intact.
+func IRFFT3To(dst *RealArray3, src *Array3) { irfft3To(dst, src, unscaled) }
+
+func irfft3To(dst *RealArray3, src *Array3, norm Norm) {
+	tmp := NewArray3(src.Dims())
+
+	p := NewPlanC2R3(tmp, dst, Estimate)
+	defer p.Destroy()
+
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm)
+}
+
+// RFFTN computes the Fourier transform of the real array src.
+// It allocates memory in which to return the half-spectrum, whose last dimension is n/2+1.
+func RFFTN(src *RealArrayN) *ArrayN {
+	dst := NewArrayN(halfDims(src.Dims()))
+	RFFTNTo(dst, src)
+
+	return dst
+}
+
+// IRFFTN computes the inverse Fourier transform of the half-spectrum src
+// and returns the real result, whose last dimension is n.
+// It allocates memory in which to return the result.
+func IRFFTN(src *ArrayN, n int) *RealArrayN {
+	dims := append([]int(nil), src.Dims()...)
+	if len(dims) > 0 {
+		dims[len(dims)-1] = n
+	}
+	dst := NewRealArrayN(dims)
+	IRFFTNTo(dst, src)
+
+	return dst
+}
+
+// RFFTNTo computes the Fourier transform of the real array src
+// and returns the half-spectrum in dst.
+func RFFTNTo(dst *ArrayN, src *RealArrayN) { rfftNTo(dst, src, unscaled) }
+
+func rfftNTo(dst *ArrayN, src *RealArrayN, norm Norm) {
+	p := NewPlanR2CN(src, dst, Estimate)
+	defer p.Destroy()
+
+	p.ExecuteNormalized(norm)
+}
+
+// IRFFTNTo computes the inverse Fourier transform of the half-spectrum src
+// and returns the real result in dst, leaving src intact.
+func IRFFTNTo(dst *RealArrayN, src *ArrayN) { irfftNTo(dst, src, unscaled) }
+
+func irfftNTo(dst *RealArrayN, src *ArrayN, norm Norm) {
+	tmp := NewArrayN(src.Dims())
+
+	p := NewPlanC2RN(tmp, dst, Estimate)
+	defer p.Destroy()
+
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm)
+}
+
+// DCT computes the type-II discrete cosine transform (REDFT10) of src,
+// without normalization.
+// It allocates memory in which to return the result.
+func DCT(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	DCTTo(dst, src)
+
+	return dst
+}
+
+// IDCT computes the inverse of DCT, so that IDCT(DCT(x)) reproduces x.
+// It allocates memory in which to return the result.
+func IDCT(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	IDCTTo(dst, src)
+
+	return dst
+}
+
+// DCTTo computes the type-II discrete cosine transform of src
+// and returns the result in dst.
+func DCTTo(dst, src *RealArray) { r2rTo(dst, src, REDFT10, false) }
+
+// IDCTTo computes the inverse of DCT
+// and returns the result in dst.
+func IDCTTo(dst, src *RealArray) { r2rTo(dst, src, REDFT01, true) }
 
-func fftNDir(dst, src *ArrayN, dir Direction) {
-	p := NewPlanN(src, dst, dir, Estimate)
+// DST computes the type-II discrete sine transform (RODFT10) of src,
+// without normalization.
+// It allocates memory in which to return the result.
+func DST(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	DSTTo(dst, src)
+
+	return dst
+}
+
+// IDST computes the inverse of DST, so that IDST(DST(x)) reproduces x.
+// It allocates memory in which to return the result.
+func IDST(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	IDSTTo(dst, src)
+
+	return dst
+}
+
+// DSTTo computes the type-II discrete sine transform of src
+// and returns the result in dst.
+func DSTTo(dst, src *RealArray) { r2rTo(dst, src, RODFT10, false) }
+
+// IDSTTo computes the inverse of DST
+// and returns the result in dst.
+func IDSTTo(dst, src *RealArray) { r2rTo(dst, src, RODFT01, true) }
+
+// r2rTo computes the real-to-real transform of src into dst. If normalize is
+// set, the result is divided by the logical size of the transform.
+func r2rTo(dst, src *RealArray, kind Kind, normalize bool) {
+	p := NewPlanR2R(src, dst, kind, Estimate)
 	defer p.Destroy()
 
 	p.Execute()
+
+	if normalize {
+		scale := 1 / float64(kind.logicalSize(dst.Len()))
+		for i := range dst.Elems {
+			dst.Elems[i] *= scale
+		}
+	}
 }
@@ -0,0 +1,177 @@
+package fftw
+
+import "fmt"
+
+// Method selects the block convolution algorithm of a StreamFilter.
+type Method int
+
+const (
+	// OverlapAdd filters each block of input zero-padded, and adds the tail of
+	// each output block to the next ones.
+	OverlapAdd Method = iota
+	// OverlapSave filters each block of input preceded by the previous input,
+	// and discards the outputs that wrap around.
+	OverlapSave
+)
+
+func (m Method) String() string {
+	switch m {
+	case OverlapAdd:
+		return "OverlapAdd"
+	case OverlapSave:
+		return "OverlapSave"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// StreamFilter applies a FIR filter to an endless real signal, passed in chunks
+// of any size, through the DFT of blocks of the signal.
+//
+// The output is that of the direct convolution y[t] = Σ kernel[k] x[t-k],
+// delayed by Latency samples, the size of a block: the first Latency output
+// samples are zero. Feed Latency()+len(kernel)-1 zeros to flush the tail of
+// the output at the end of a signal.
+//
+// The plans, the kernel spectrum and all scratch arrays are created by
+// NewStreamFilter, so Process does not allocate. A StreamFilter is not safe for
+// concurrent use.
+type StreamFilter struct {
+	method Method
+	// The kernel length, the number of input samples of a block and the size
+	// of its DFT, which is block+taps-1.
+	taps, block, size int
+	// The DFT of the kernel, divided by size.
+	kernel []complex128
+	// The time and frequency arrays of the plans.
+	time     *RealArray
+	spectrum *Array
+	r2c, c2r *Plan
+	// The samples of the block being filled, and the output of the previous
+	// block, which Process returns as the next ones are filled.
+	pending, ready []float64
+	// The number of samples of the current block filled so far.
+	fill int
+	// The last taps-1 input samples for OverlapSave, or the tail of the output
+	// for OverlapAdd.
+	history []float64
+}
+
+// NewStreamFilter returns a filter with the given kernel that processes blocks
+// of at least block samples, rounded up so that the DFT has a size that FFTW
+// transforms fast, see FastSize. The plans are created with flag, such as
+// Measure; the filter keeps them until Destroy is called.
+func NewStreamFilter(kernel []float64, block int, method Method, flag Flag) *StreamFilter {
+	if len(kernel) == 0 {
+		panic("fftw: kernel must be non-empty")
+	}
+	if block <= 0 {
+		panic("fftw: block must be > 0")
+	}
+	if method != OverlapAdd && method != OverlapSave {
+		panic(fmt.Sprintf("fftw: invalid %v", method))
+	}
+	taps := len(kernel)
+	size := FastSize(block + taps - 1)
+	block = size - taps + 1
+	f := &StreamFilter{
+		method:   method,
+		taps:     taps,
+		block:    block,
+		size:     size,
+		kernel:   make([]complex128, size/2+1),
+		time:     NewRealArray(size),
+		spectrum: NewArray(size/2 + 1),
+		r2c:      nil,
+		c2r:      nil,
+		pending:  make([]float64, block),
+		ready:    make([]float64, block),
+		fill:     0,
+		history:  make([]float64, taps-1),
+	}
+	f.r2c = NewPlanR2C(f.time, f.spectrum, flag)
+	f.c2r = NewPlanC2R(f.spectrum, f.time, flag)
+
+	copy(f.time.Elems, kernel)
+	clear(f.time.Elems[taps:])
+	f.r2c.Execute()
+	for i, x := range f.spectrum.Elems {
+		f.kernel[i] = x / complex(float64(size), 0)
+	}
+	return f
+}
+
+// Latency returns the delay of the output of f, in samples: the number of input
+// samples of a block.
+func (f *StreamFilter) Latency() int {
+	return f.block
+}
+
+// Process filters the samples src and writes as many output samples to dst,
+// which must be at least as long. dst and src may be the same slice.
+func (f *StreamFilter) Process(dst, src []float64) {
+	if len(dst) < len(src) {
+		panic("fftw: output must be at least as long as input")
+	}
+	for len(src) > 0 {
+		// The input is saved before the output is written, as dst may be src.
+		n := copy(f.pending[f.fill:], src)
+		copy(dst[:n], f.ready[f.fill:])
+		src, dst = src[n:], dst[n:]
+		f.fill += n
+		if f.fill == f.block {
+			f.filterBlock()
+			f.fill = 0
+		}
+	}
+}
+
+// Reset clears the state of f, as if no sample had been processed.
+func (f *StreamFilter) Reset() {
+	clear(f.ready)
+	clear(f.history)
+	f.fill = 0
+}
+
+// Destroy destroys the plans of f, which must not be used afterwards.
+func (f *StreamFilter) Destroy() {
+	f.r2c.Destroy()
+	f.c2r.Destroy()
+}
+
+// filterBlock filters the pending block into ready.
+func (f *StreamFilter) filterBlock() {
+	x := f.time.Elems
+	if f.method == OverlapSave {
+		copy(x, f.history)
+		copy(x[f.taps-1:], f.pending)
+		copy(f.history, x[f.block:])
+	} else {
+		copy(x, f.pending)
+		clear(x[f.block:])
+	}
+
+	f.r2c.Execute()
+	for i, h := range f.kernel {
+		f.spectrum.Elems[i] *= h
+	}
+	f.c2r.Execute()
+
+	if f.method == OverlapSave {
+		// The first taps-1 outputs wrap around the block.
+		copy(f.ready, x[f.taps-1:])
+		return
+	}
+	// The tail spans the next taps-1 outputs, which may be more than a block.
+	for i := range f.ready {
+		f.ready[i] = x[i]
+		if i < len(f.history) {
+			f.ready[i] += f.history[i]
+		}
+	}
+	for i := range f.history {
+		f.history[i] = x[f.block+i]
+		if i+f.block < len(f.history) {
+			f.history[i] += f.history[i+f.block]
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package fftw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStreamFilter(t *testing.T) {
+	t.Parallel()
+
+	const n = 1000
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(float64(i*i)) + 0.5
+	}
+
+	for _, method := range []Method{OverlapAdd, OverlapSave} {
+		for _, tc := range []struct {
+			taps, block int
+			chunks      []int
+		}{
+			{1, 1, []int{1}},
+			{5, 8, []int{1, 7, 50}},
+			{33, 16, []int{3, 100}},
+			{40, 3, []int{13}},
+			{64, 200, []int{999, 1}},
+		} {
+			kernel := make([]float64, tc.taps)
+			for i := range kernel {
+				kernel[i] = math.Cos(float64(3*i)) / float64(tc.taps)
+			}
+
+			f := NewStreamFilter(kernel, tc.block, method, Measure)
+			latency := f.Latency()
+			if latency < tc.block {
+				t.Fatalf("%v %d taps: latency %d below block %d", method, tc.taps, latency, tc.block)
+			}
+
+			// Filter the signal in chunks of varying sizes, in place.
+			y := append([]float64(nil), x...)
+			for i, c := 0, 0; i < n; c++ {
+				m := min(tc.chunks[c%len(tc.chunks)], n-i)
+				f.Process(y[i:i+m], y[i:i+m])
+				i += m
+			}
+			f.Destroy()
+
+			for i, got := range y {
+				var want float64
+				for k, h := range kernel {
+					if j := i - latency - k; j >= 0 {
+						want += h * x[j]
+					}
+				}
+				if math.Abs(got-want) > 1e-10 {
+					t.Fatalf("%v %d taps, block %d: y[%d] = %v, want %v", method, tc.taps, tc.block, i, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestStreamFilterReset(t *testing.T) {
+	t.Parallel()
+
+	f := NewStreamFilter([]float64{1, 2, 3}, 6, OverlapAdd, Estimate)
+	defer f.Destroy()
+
+	x := []float64{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	y := make([]float64, len(x))
+	f.Process(y, x)
+	f.Reset()
+	z := make([]float64, len(x))
+	f.Process(z, x)
+
+	for i := range y {
+		testAlmostEqual(t, z[i], y[i])
+	}
+	// The impulse response, after the latency.
+	for k, h := range []float64{1, 2, 3} {
+		testAlmostEqual(t, y[f.Latency()+k], h)
+	}
+}
+
+func TestStreamFilterAllocs(t *testing.T) {
+	f := NewStreamFilter(make([]float64, 31), 97, OverlapSave, Estimate)
+	defer f.Destroy()
+
+	buf := make([]float64, 50)
+	if allocs := testing.AllocsPerRun(100, func() { f.Process(buf, buf) }); allocs != 0 {
+		t.Fatalf("Process allocates %v times, want 0", allocs)
+	}
+}
+
+func TestStreamFilterPanics(t *testing.T) {
+	t.Parallel()
+
+	expectPanic(t, "empty kernel", func() { NewStreamFilter(nil, 8, OverlapAdd, Estimate) })
+	expectPanic(t, "zero block", func() { NewStreamFilter([]float64{1}, 0, OverlapAdd, Estimate) })
+	expectPanic(t, "invalid method", func() { NewStreamFilter([]float64{1}, 8, Method(2), Estimate) })
+
+	f := NewStreamFilter([]float64{1}, 8, OverlapSave, Estimate)
+	defer f.Destroy()
+	expectPanic(t, "short output", func() { f.Process(make([]float64, 2), make([]float64, 3)) })
+}
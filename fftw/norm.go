@@ -0,0 +1,281 @@
+package fftw
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Norm selects the scaling of a transform of n elements, with the semantics of
+// the norm argument of numpy.fft. FFTW itself never scales, so that a forward
+// transform followed by a backward one multiplies the data by n.
+type Norm int
+
+const (
+	// NormBackward leaves forward transforms unscaled and scales backward ones
+	// by 1/n, so that a round trip reproduces the data.
+	NormBackward Norm = iota
+	// NormOrtho scales both directions by 1/sqrt(n), which makes them unitary.
+	NormOrtho
+	// NormForward scales forward transforms by 1/n and leaves backward ones unscaled.
+	NormForward
+)
+
+// unscaled leaves both directions unscaled, as Execute and the helpers without
+// a Norm argument do.
+const unscaled Norm = -1
+
+func (n Norm) String() string {
+	switch n {
+	case NormBackward:
+		return "Backward"
+	case NormOrtho:
+		return "Ortho"
+	case NormForward:
+		return "Forward"
+	}
+	return fmt.Sprintf("Norm(%d)", int(n))
+}
+
+// factor returns the scale of a transform of size elements in direction dir.
+func (n Norm) factor(size int, dir Direction) float64 {
+	switch {
+	case n < unscaled || n > NormForward:
+		panic(fmt.Sprintf("fftw: invalid %v", n))
+	case n == NormOrtho:
+		return 1 / math.Sqrt(float64(size))
+	case n == NormBackward && dir == Backward, n == NormForward && dir == Forward:
+		return 1 / float64(size)
+	}
+	return 1
+}
+
+// scaling is the size and output array of a plan, which ExecuteNormalized scales.
+type scaling struct {
+	size int
+	dir  Direction
+	// The output array, with complex elements seen as pairs of reals.
+	out []float64
+}
+
+func complexScaling(size int, dir Direction, out []complex128) scaling {
+	return scaling{size, dir, complexFloats(out)}
+}
+
+func realScaling(size int, out []float64) scaling {
+	return scaling{size, Backward, out}
+}
+
+func complexFloats(x []complex128) []float64 {
+	return unsafe.Slice((*float64)(unsafe.Pointer(unsafe.SliceData(x))), 2*len(x))
+}
+
+// scale multiplies x by f in place.
+func scale(x []float64, f float64) {
+	if f == 1 {
+		return
+	}
+	for i := range x {
+		x[i] *= f
+	}
+}
+
+// ExecuteNormalized executes the plan p like Execute, then scales its output as
+// norm requires for the number of elements of the transform, in a single pass
+// and without allocating.
+//
+// Only plans created by NewPlan, NewPlanR2C, NewPlanC2R and their 2D, 3D and
+// N-dimensional versions know their size; ExecuteNormalized panics for others.
+func (p *Plan) ExecuteNormalized(norm Norm) *Plan {
+	if p.scaling.size == 0 {
+		panic(fmt.Sprintf("fftw: %v: ExecuteNormalized needs a plan from NewPlan, NewPlanR2C or NewPlanC2R", ErrPlanKind))
+	}
+	p.Execute()
+	scale(p.scaling.out, norm.factor(p.scaling.size, p.scaling.dir))
+	return p
+}
+
+// FFTNorm is the version of FFT that scales the result as norm requires.
+func FFTNorm(src *Array, norm Norm) *Array {
+	dst := NewArray(src.Len())
+	FFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// IFFTNorm is the version of IFFT that scales the result as norm requires.
+// With NormBackward, IFFTNorm(FFTNorm(x, NormBackward), NormBackward) reproduces x.
+func IFFTNorm(src *Array, norm Norm) *Array {
+	dst := NewArray(src.Len())
+	IFFTNormTo(dst, src, norm)
+
+	return dst
+}
+
+// FFTNormTo is the version of FFTTo that scales the result as norm requires.
+func FFTNormTo(dst, src *Array, norm Norm) { fftDir(dst, src, Forward, norm) }
+
+// IFFTNormTo is the version of IFFTTo that scales the result as norm requires.
+func IFFTNormTo(dst, src *Array, norm Norm) { fftDir(dst, src, Backward, norm) }
+
+// 2D version of FFTNorm.
+func FFT2Norm(src *Array2, norm Norm) *Array2 {
+	dst := NewArray2(src.Dims())
+	FFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of IFFTNorm.
+func IFFT2Norm(src *Array2, norm Norm) *Array2 {
+	dst := NewArray2(src.Dims())
+	IFFT2NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of FFTNormTo.
+func FFT2NormTo(dst, src *Array2, norm Norm) { fft2Dir(dst, src, Forward, norm) }
+
+// 2D version of IFFTNormTo.
+func IFFT2NormTo(dst, src *Array2, norm Norm) { fft2Dir(dst, src, Backward, norm) }
+
+// 3D version of FFTNorm.
+func FFT3Norm(src *Array3, norm Norm) *Array3 {
+	dst := NewArray3(src.Dims())
+	FFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of IFFTNorm.
+func IFFT3Norm(src *Array3, norm Norm) *Array3 {
+	dst := NewArray3(src.Dims())
+	IFFT3NormTo(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of FFTNormTo.
+func FFT3NormTo(dst, src *Array3, norm Norm) { fft3Dir(dst, src, Forward, norm) }
+
+// 3D version of IFFTNormTo.
+func IFFT3NormTo(dst, src *Array3, norm Norm) { fft3Dir(dst, src, Backward, norm) }
+
+// N-dimensional version of FFTNorm.
+func FFTNNorm(src *ArrayN, norm Norm) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	FFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of IFFTNorm.
+func IFFTNNorm(src *ArrayN, norm Norm) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	IFFTNNormTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of FFTNormTo.
+func FFTNNormTo(dst, src *ArrayN, norm Norm) { fftNDir(dst, src, Forward, norm) }
+
+// N-dimensional version of IFFTNormTo.
+func IFFTNNormTo(dst, src *ArrayN, norm Norm) { fftNDir(dst, src, Backward, norm) }
+
+// RFFTNorm is the version of RFFT that scales the result as norm requires.
+func RFFTNorm(src *RealArray, norm Norm) *Array {
+	dst := NewArray(src.Len()/2 + 1)
+	rfftTo(dst, src, norm)
+
+	return dst
+}
+
+// IRFFTNorm is the version of IRFFT that scales the result as norm requires.
+func IRFFTNorm(src *Array, n int, norm Norm) *RealArray {
+	dst := NewRealArray(n)
+	irfftTo(dst, src, norm)
+
+	return dst
+}
+
+// RFFTNormTo is the version of RFFTTo that scales the result as norm requires.
+func RFFTNormTo(dst *Array, src *RealArray, norm Norm) { rfftTo(dst, src, norm) }
+
+// IRFFTNormTo is the version of IRFFTTo that scales the result as norm requires.
+func IRFFTNormTo(dst *RealArray, src *Array, norm Norm) { irfftTo(dst, src, norm) }
+
+// 2D version of RFFTNorm.
+func RFFT2Norm(src *RealArray2, norm Norm) *Array2 {
+	n0, n1 := src.Dims()
+	dst := NewArray2(n0, n1/2+1)
+	rfft2To(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of IRFFTNorm.
+func IRFFT2Norm(src *Array2, n1 int, norm Norm) *RealArray2 {
+	n0, _ := src.Dims()
+	dst := NewRealArray2(n0, n1)
+	irfft2To(dst, src, norm)
+
+	return dst
+}
+
+// 2D version of RFFTNormTo.
+func RFFT2NormTo(dst *Array2, src *RealArray2, norm Norm) { rfft2To(dst, src, norm) }
+
+// 2D version of IRFFTNormTo.
+func IRFFT2NormTo(dst *RealArray2, src *Array2, norm Norm) { irfft2To(dst, src, norm) }
+
+// 3D version of RFFTNorm.
+func RFFT3Norm(src *RealArray3, norm Norm) *Array3 {
+	n0, n1, n2 := src.Dims()
+	dst := NewArray3(n0, n1, n2/2+1)
+	rfft3To(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of IRFFTNorm.
+func IRFFT3Norm(src *Array3, n2 int, norm Norm) *RealArray3 {
+	n0, n1, _ := src.Dims()
+	dst := NewRealArray3(n0, n1, n2)
+	irfft3To(dst, src, norm)
+
+	return dst
+}
+
+// 3D version of RFFTNormTo.
+func RFFT3NormTo(dst *Array3, src *RealArray3, norm Norm) { rfft3To(dst, src, norm) }
+
+// 3D version of IRFFTNormTo.
+func IRFFT3NormTo(dst *RealArray3, src *Array3, norm Norm) { irfft3To(dst, src, norm) }
+
+// N-dimensional version of RFFTNorm.
+func RFFTNNorm(src *RealArrayN, norm Norm) *ArrayN {
+	dst := NewArrayN(halfDims(src.Dims()))
+	rfftNTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of IRFFTNorm.
+func IRFFTNNorm(src *ArrayN, n int, norm Norm) *RealArrayN {
+	dims := append([]int(nil), src.Dims()...)
+	if len(dims) > 0 {
+		dims[len(dims)-1] = n
+	}
+	dst := NewRealArrayN(dims)
+	irfftNTo(dst, src, norm)
+
+	return dst
+}
+
+// N-dimensional version of RFFTNormTo.
+func RFFTNNormTo(dst *ArrayN, src *RealArrayN, norm Norm) { rfftNTo(dst, src, norm) }
+
+// N-dimensional version of IRFFTNormTo.
+func IRFFTNNormTo(dst *RealArrayN, src *ArrayN, norm Norm) { irfftNTo(dst, src, norm) }
@@ -0,0 +1,78 @@
+package fftw
+
+import "testing"
+
+func TestFFTWith(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	c := NewPlanCache(4)
+	defer c.Close()
+
+	x := cosArray(n)
+	opts := []Option{WithFlag(Measure), WithThreads(2), WithCache(c)}
+	peakVerifier(t, FFTWith(x, opts...).Elems)
+	peakVerifier(t, FFTWith(x, opts...).Elems)
+
+	// Measure overwrites the arrays while planning, but the input is restored.
+	testAlmostEqual(t, real(x.Elems[0]), 1)
+
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 1 || s.Len != 1 {
+		t.Fatalf("Stats = %+v, want 1 hit, 1 miss, 1 plan", s)
+	}
+
+	// The thread count is part of the flags of the plan.
+	FFTWith(x, WithFlag(Measure|Threads(4)), WithThreads(2), WithCache(c))
+	FFTWith(x, WithFlag(Measure), WithCache(c))
+
+	if s := c.Stats(); s.Hits != 2 || s.Len != 2 {
+		t.Fatalf("Stats = %+v, want 2 hits, 2 plans", s)
+	}
+
+	y := FFTWith(x, WithNorm(NormOrtho), WithCache(nil))
+	testAlmostEqual(t, real(y.Elems[1]), n/2/4)
+}
+
+func TestFFTWithRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	x2 := NewArray2(4, 6)
+	x3 := NewArray3(2, 3, 4)
+	xn := NewArrayN([]int{3, 2, 2, 2})
+	for _, x := range [][]complex128{x2.Elems, x3.Elems, xn.Elems} {
+		for i := range x {
+			x[i] = complex(float64(i%5), float64(i%3)-1)
+		}
+	}
+
+	for _, norm := range []Norm{NormBackward, NormOrtho, NormForward} {
+		opt := WithNorm(norm)
+		for _, tc := range []struct {
+			x, y []complex128
+		}{
+			{x2.Elems, IFFT2With(FFT2With(x2, opt), opt).Elems},
+			{x3.Elems, IFFT3With(FFT3With(x3, opt), opt).Elems},
+			{xn.Elems, IFFTNWith(FFTNWith(xn, opt), opt).Elems},
+		} {
+			for i := range tc.x {
+				testAlmostEqual(t, real(tc.y[i]), real(tc.x[i]))
+				testAlmostEqual(t, imag(tc.y[i]), imag(tc.x[i]))
+			}
+		}
+	}
+
+	// In place, the inverse is unscaled by default.
+	y := cosArray(8)
+	FFTWithTo(y, y)
+	IFFTWithTo(y, y)
+	testAlmostEqual(t, real(y.Elems[0]), 8)
+}
+
+func TestOptionPanics(t *testing.T) {
+	t.Parallel()
+
+	expectPanic(t, "zero threads", func() { WithThreads(0) })
+	expectPanic(t, "too many threads", func() { WithThreads(MaxThreads + 1) })
+	expectPanic(t, "invalid norm", func() { WithNorm(Norm(3)) })
+}
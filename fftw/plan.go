@@ -1,11 +1,15 @@
+//go:build cgo && !purego
+
 package fftw
 
 // #include <fftw3.h>
 import "C"
 
 import (
+	"fmt"
 	"runtime"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -16,9 +20,31 @@ import (
 //nolint:gochecknoglobals
 var createDestroyMu sync.Mutex
 
+// NoTimeLimit removes the planning time limit when passed to SetTimeLimit.
+const NoTimeLimit time.Duration = -1
+
+// SetTimeLimit bounds the time the planner may spend creating each plan with
+// Measure, Patient or Exhaustive, after which it returns the best plan found so
+// far. The limit is approximate and applies to plans created afterwards.
+func SetTimeLimit(d time.Duration) {
+	seconds := C.double(C.FFTW_NO_TIMELIMIT)
+	if d >= 0 {
+		seconds = C.double(d.Seconds())
+	}
+	createDestroyMu.Lock()
+	C.fftw_set_timelimit(seconds)
+	createDestroyMu.Unlock()
+}
+
 type Plan struct {
 	fftwP C.fftw_plan
 	pin   runtime.Pinner
+	// The arrays the plan was created for, to check those passed to ExecuteOn.
+	layout layout
+	// The memory of the arrays allocated by FFTW, kept until the plan is destroyed.
+	allocs []*allocation
+	// The size and output of the transform, for ExecuteNormalized.
+	scaling scaling
 }
 
 // NewPlanForSize allocates input/output arrays of length n and returns a plan for them.
@@ -35,81 +61,115 @@ func NewPlanForSize(n int, dir Direction, flag Flag) (p *Plan, in *Array, out *A
 	return p, in, out
 }
 
+// NewPlan returns a plan for the DFT of in, written to out.
+// It panics if the arrays are unsuitable or FFTW cannot create the plan;
+// TryNewPlan returns an error instead.
 func NewPlan(in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan(in, out, dir, flag))
+}
+
+// 2D version of NewPlan.
+func NewPlan2(in, out *Array2, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan2(in, out, dir, flag))
+}
+
+// 3D version of NewPlan.
+func NewPlan3(in, out *Array3, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan3(in, out, dir, flag))
+}
+
+// N-dimensional version of NewPlan.
+func NewPlanN(in, out *ArrayN, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanN(in, out, dir, flag))
+}
+
+// TryNewPlan is the version of NewPlan that returns an error instead of panicking.
+//
+// It returns ErrEmpty for nil or empty arrays, ErrDimensionsMismatch if their
+// lengths differ, and ErrNoPlan if FFTW cannot create the plan, which happens
+// for example with WisdomOnly when no wisdom is available.
+func TryNewPlan(in, out *Array, dir Direction, flag Flag) (*Plan, error) {
 	if in == nil || out == nil {
-		panic("fftw: input and output must be non-nil")
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
 	}
 	if in.Len() == 0 {
-		panic("fftw: input and output must be non-empty")
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
 	}
 	if in.Len() != out.Len() {
-		panic("fftw: input and output lengths must match")
+		return nil, fmt.Errorf("%w: input length %d, output length %d", ErrDimensionsMismatch, in.Len(), out.Len())
 	}
 	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
-	plan.pin.Pin(in.ptr())
-	plan.pin.Pin(out.ptr())
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
 	n := in.Len()
 	var (
 		numElems = C.int(n)
 		inPtr    = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
 		outPtr   = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
 		dir_     = C.int(dir)
-		flag_    = C.uint(flag)
+		flag_    = cFlag(flag)
 	)
-	createDestroyMu.Lock()
+	plan.layout = newLayout(flag, dftPlan, shape([]int{n}, false), shape([]int{n}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(n, dir, out.Elems)
+	lockPlanner(flag)
 	plan.fftwP = C.fftw_plan_dft_1d(numElems, inPtr, outPtr, dir_, flag_)
-	createDestroyMu.Unlock()
-	runtime.SetFinalizer(plan, planFinalizer)
+	unlockPlanner(flag)
 
-	return plan
+	return plan.finish()
 }
 
-func NewPlan2(in, out *Array2, dir Direction, flag Flag) *Plan {
+// 2D version of TryNewPlan.
+func TryNewPlan2(in, out *Array2, dir Direction, flag Flag) (*Plan, error) {
 	if in == nil || out == nil {
-		panic("fftw: input and output must be non-nil")
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
 	}
 	in0, in1 := in.Dims()
 	out0, out1 := out.Dims()
 	if in0 <= 0 || in1 <= 0 {
-		panic("fftw: input and output must be non-empty")
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
 	}
 	if in0 != out0 || in1 != out1 {
-		panic("fftw: input and output dimensions must match")
+		return nil, fmt.Errorf("%w: input (%d,%d), output (%d,%d)", ErrDimensionsMismatch, in0, in1, out0, out1)
 	}
 	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
-	plan.pin.Pin(in.ptr())
-	plan.pin.Pin(out.ptr())
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
 	var (
 		dim0   = C.int(in0)
 		dim1   = C.int(in1)
 		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
 		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
 		dir_   = C.int(dir)
-		flag_  = C.uint(flag)
+		flag_  = cFlag(flag)
 	)
-	createDestroyMu.Lock()
+	plan.layout = newLayout(flag, dftPlan, shape(in.N[:], false), shape(out.N[:], false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(in.N[:]), dir, out.Elems)
+	lockPlanner(flag)
 	plan.fftwP = C.fftw_plan_dft_2d(dim0, dim1, inPtr, outPtr, dir_, flag_)
-	createDestroyMu.Unlock()
-	runtime.SetFinalizer(plan, planFinalizer)
+	unlockPlanner(flag)
 
-	return plan
+	return plan.finish()
 }
 
-func NewPlan3(in, out *Array3, dir Direction, flag Flag) *Plan {
+// 3D version of TryNewPlan.
+func TryNewPlan3(in, out *Array3, dir Direction, flag Flag) (*Plan, error) {
 	if in == nil || out == nil {
-		panic("fftw: input and output must be non-nil")
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
 	}
 	in0, in1, in2 := in.Dims()
 	out0, out1, out2 := out.Dims()
 	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
-		panic("fftw: input and output must be non-empty")
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
 	}
 	if in0 != out0 || in1 != out1 || in2 != out2 {
-		panic("fftw: input and output dimensions must match")
+		return nil, fmt.Errorf("%w: input (%d,%d,%d), output (%d,%d,%d)", ErrDimensionsMismatch,
+			in0, in1, in2, out0, out1, out2)
 	}
 	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
-	plan.pin.Pin(in.ptr())
-	plan.pin.Pin(out.ptr())
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
 	var (
 		dim0   = C.int(in0)
 		dim1   = C.int(in1)
@@ -117,56 +177,74 @@ func NewPlan3(in, out *Array3, dir Direction, flag Flag) *Plan {
 		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
 		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
 		dir_   = C.int(dir)
-		flag_  = C.uint(flag)
+		flag_  = cFlag(flag)
 	)
-	createDestroyMu.Lock()
+	plan.layout = newLayout(flag, dftPlan, shape(in.N[:], false), shape(out.N[:], false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(in.N[:]), dir, out.Elems)
+	lockPlanner(flag)
 	plan.fftwP = C.fftw_plan_dft_3d(dim0, dim1, dim2, inPtr, outPtr, dir_, flag_)
-	createDestroyMu.Unlock()
-	runtime.SetFinalizer(plan, planFinalizer)
+	unlockPlanner(flag)
 
-	return plan
+	return plan.finish()
 }
 
-func NewPlanN(in, out *ArrayN, dir Direction, flag Flag) *Plan {
+// N-dimensional version of TryNewPlan.
+func TryNewPlanN(in, out *ArrayN, dir Direction, flag Flag) (*Plan, error) {
 	if in == nil || out == nil {
-		panic("fftw: input and output must be non-nil")
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
 	}
 	inDims := in.Dims()
 	outDims := out.Dims()
 	if len(inDims) == 0 {
-		panic("fftw: input and output must be non-empty")
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
 	}
-	if len(inDims) != len(outDims) {
-		panic("fftw: input and output dimensions must match")
-	}
-	for i := range inDims {
-		if inDims[i] <= 0 {
-			panic("fftw: input and output must be non-empty")
-		}
-		if inDims[i] != outDims[i] {
-			panic("fftw: input and output dimensions must match")
+	for _, d := range inDims {
+		if d <= 0 {
+			return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
 		}
 	}
-	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
-	plan.pin.Pin(in.ptr())
-	plan.pin.Pin(out.ptr())
-	numElems := make([]C.int, len(inDims))
-	for i := range inDims {
-		numElems[i] = C.int(inDims[i])
+	if !equalDims(inDims, outDims) {
+		return nil, fmt.Errorf("%w: input %v, output %v", ErrDimensionsMismatch, inDims, outDims)
 	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(inDims)
 	var (
 		rank   = C.int(len(inDims))
 		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
 		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
 		dir_   = C.int(dir)
-		flag_  = C.uint(flag)
+		flag_  = cFlag(flag)
 	)
-	createDestroyMu.Lock()
+	plan.layout = newLayout(flag, dftPlan, shape(inDims, false), shape(outDims, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(inDims), dir, out.Elems)
+	lockPlanner(flag)
 	plan.fftwP = C.fftw_plan_dft(rank, &numElems[0], inPtr, outPtr, dir_, flag_)
-	createDestroyMu.Unlock()
-	runtime.SetFinalizer(plan, planFinalizer)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
 
-	return plan
+// finish sets up the finalizer of a newly created plan, or releases it and
+// returns ErrNoPlan if FFTW could not create it.
+func (p *Plan) finish() (*Plan, error) {
+	if p.fftwP == nil {
+		p.unhold()
+		return nil, ErrNoPlan
+	}
+	runtime.SetFinalizer(p, planFinalizer)
+	return p, nil
+}
+
+// mustPlan panics if err is not nil, for the constructors that panic on error.
+func mustPlan(p *Plan, err error) *Plan {
+	if err != nil {
+		panic("fftw: " + err.Error())
+	}
+	return p
 }
 
 func (p *Plan) Execute() *Plan {
@@ -199,7 +277,14 @@ func (p *Plan) Destroy() {
 	}
 	p.fftwP = nil
 	createDestroyMu.Unlock()
-	p.pin.Unpin()
+	p.unhold()
+}
+
+// detach releases the arrays p was created for, which it must then only be
+// executed with ExecuteOn and its variants.
+func (p *Plan) detach() {
+	p.unhold()
+	p.scaling = scaling{}
 }
 
 func planFinalizer(p *Plan) {
@@ -0,0 +1,396 @@
+//go:build cgo && !purego
+
+package fftw
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+// NewPlanGuru returns a plan for the transforms of rank len(dims), repeated over
+// the loop described by howmany, reading from in and writing to out.
+//
+// This is FFTW's most general interface, which can describe strided views such as
+// the columns of a matrix. Every element the plan may access is checked to lie
+// within in.Elems and out.Elems.
+func NewPlanGuru(dims, howmany []IODim, in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru(dims, howmany, in, out, dir, flag))
+}
+
+// TryNewPlanGuru is the version of NewPlanGuru that returns ErrNoPlan instead
+// of panicking if FFTW cannot create the plan. Invalid arguments still panic.
+func TryNewPlanGuru(dims, howmany []IODim, in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(widenIODims(dims), widenIODims(howmany), in.Len(), out.Len(), false, false, 0)
+	cDims, cHowmany := cIODims(dims), cIODims(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		dir_        = C.int(dir)
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, dftPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru_dft(rank, firstIODim(cDims), howmanyRank, firstIODim(cHowmany),
+		inPtr, outPtr, dir_, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuru64 is the version of NewPlanGuru with 64-bit sizes and strides.
+func NewPlanGuru64(dims, howmany []IODim64, in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64(dims, howmany, in, out, dir, flag))
+}
+
+// TryNewPlanGuru64 is the version of TryNewPlanGuru with 64-bit sizes and strides.
+func TryNewPlanGuru64(dims, howmany []IODim64, in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(dims, howmany, in.Len(), out.Len(), false, false, 0)
+	cDims, cHowmany := cIODims64(dims), cIODims64(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		dir_        = C.int(dir)
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, dftPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru64_dft(rank, firstIODim64(cDims), howmanyRank, firstIODim64(cHowmany),
+		inPtr, outPtr, dir_, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuruR2C returns a guru plan for real-to-complex transforms.
+//
+// The lengths in dims are those of the real input; along the last dimension the
+// complex output only holds n/2+1 elements.
+func NewPlanGuruR2C(dims, howmany []IODim, in *RealArray, out *Array, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruR2C(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuruR2C is the version of NewPlanGuruR2C that returns ErrNoPlan
+// instead of panicking if FFTW cannot create the plan. Invalid arguments still
+// panic.
+func TryNewPlanGuruR2C(dims, howmany []IODim, in *RealArray, out *Array, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(widenIODims(dims), widenIODims(howmany), in.Len(), out.Len(), false, true, 1)
+	cDims, cHowmany := cIODims(dims), cIODims(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru_dft_r2c(rank, firstIODim(cDims), howmanyRank, firstIODim(cHowmany),
+		inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuru64R2C is the version of NewPlanGuruR2C with 64-bit sizes and strides.
+func NewPlanGuru64R2C(dims, howmany []IODim64, in *RealArray, out *Array, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64R2C(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuru64R2C is the version of TryNewPlanGuruR2C with 64-bit sizes and strides.
+func TryNewPlanGuru64R2C(dims, howmany []IODim64, in *RealArray, out *Array, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(dims, howmany, in.Len(), out.Len(), false, true, 1)
+	cDims, cHowmany := cIODims64(dims), cIODims64(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru64_dft_r2c(rank, firstIODim64(cDims), howmanyRank, firstIODim64(cHowmany),
+		inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuruC2R returns a guru plan for complex-to-real transforms.
+//
+// The lengths in dims are those of the real output; along the last dimension the
+// complex input only holds n/2+1 elements.
+// Beware that FFTW overwrites the input of complex-to-real transforms.
+func NewPlanGuruC2R(dims, howmany []IODim, in *Array, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruC2R(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuruC2R is the version of NewPlanGuruC2R that returns ErrNoPlan
+// instead of panicking if FFTW cannot create the plan. Invalid arguments still
+// panic.
+func TryNewPlanGuruC2R(dims, howmany []IODim, in *Array, out *RealArray, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(widenIODims(dims), widenIODims(howmany), in.Len(), out.Len(), true, false, 1)
+	cDims, cHowmany := cIODims(dims), cIODims(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru_dft_c2r(rank, firstIODim(cDims), howmanyRank, firstIODim(cHowmany),
+		inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuru64C2R is the version of NewPlanGuruC2R with 64-bit sizes and strides.
+func NewPlanGuru64C2R(dims, howmany []IODim64, in *Array, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64C2R(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuru64C2R is the version of TryNewPlanGuruC2R with 64-bit sizes and strides.
+func TryNewPlanGuru64C2R(dims, howmany []IODim64, in *Array, out *RealArray, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(dims, howmany, in.Len(), out.Len(), true, false, 1)
+	cDims, cHowmany := cIODims64(dims), cIODims64(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru64_dft_c2r(rank, firstIODim64(cDims), howmanyRank, firstIODim64(cHowmany),
+		inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuruR2R returns a guru plan for real-to-real transforms, applying
+// kinds[i] along dims[i].
+func NewPlanGuruR2R(dims, howmany []IODim, in, out *RealArray, kinds []Kind, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruR2R(dims, howmany, in, out, kinds, flag))
+}
+
+// TryNewPlanGuruR2R is the version of NewPlanGuruR2R that returns ErrNoPlan
+// instead of panicking if FFTW cannot create the plan. Invalid arguments still
+// panic.
+func TryNewPlanGuruR2R(dims, howmany []IODim, in, out *RealArray, kinds []Kind, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(widenIODims(dims), widenIODims(howmany), in.Len(), out.Len(), false, false, 0)
+	kinds_ := cKinds(kinds, widenIODims(dims))
+	cDims, cHowmany := cIODims(dims), cIODims(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru_r2r(rank, firstIODim(cDims), howmanyRank, firstIODim(cHowmany),
+		inPtr, outPtr, firstKind(kinds_), flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// NewPlanGuru64R2R is the version of NewPlanGuruR2R with 64-bit sizes and strides.
+func NewPlanGuru64R2R(dims, howmany []IODim64, in, out *RealArray, kinds []Kind, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64R2R(dims, howmany, in, out, kinds, flag))
+}
+
+// TryNewPlanGuru64R2R is the version of TryNewPlanGuruR2R with 64-bit sizes and strides.
+func TryNewPlanGuru64R2R(dims, howmany []IODim64, in, out *RealArray, kinds []Kind, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	validateGuru(dims, howmany, in.Len(), out.Len(), false, false, 0)
+	kinds_ := cKinds(kinds, dims)
+	cDims, cHowmany := cIODims64(dims), cIODims64(howmany)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		rank        = C.int(len(dims))
+		howmanyRank = C.int(len(howmany))
+		inPtr       = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr      = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_       = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_guru64_r2r(rank, firstIODim64(cDims), howmanyRank, firstIODim64(cHowmany),
+		inPtr, outPtr, firstKind(kinds_), flag_)
+	unlockPlanner(flag)
+
+	return plan.finish()
+}
+
+// validateGuru panics unless every element accessed by a guru plan lies within
+// input and output slices of the given lengths. If inHalf or outHalf is set, that
+// side is the complex side of a real transform, with n/2+1 elements along the
+// last dimension.
+func validateGuru(dims, howmany []IODim64, inLen, outLen int, inHalf, outHalf bool, minRank int) {
+	if len(dims) < minRank {
+		panic(fmt.Sprintf("fftw: transform must have at least %d dimension(s)", minRank))
+	}
+	for _, d := range append(append([]IODim64(nil), dims...), howmany...) {
+		if d.N <= 0 {
+			panic("fftw: input and output must be non-empty")
+		}
+	}
+	checkGuruBounds("input", dims, howmany, inLen, inHalf, func(d IODim64) int { return d.Is })
+	checkGuruBounds("output", dims, howmany, outLen, outHalf, func(d IODim64) int { return d.Os })
+}
+
+func checkGuruBounds(name string, dims, howmany []IODim64, length int, half bool, stride func(IODim64) int) {
+	var lo, hi int
+	span := func(n, s int) {
+		if s < 0 {
+			lo += (n - 1) * s
+		} else {
+			hi += (n - 1) * s
+		}
+	}
+	for i, d := range dims {
+		n := d.N
+		if half && i == len(dims)-1 {
+			n = n/2 + 1
+		}
+		span(n, stride(d))
+	}
+	for _, d := range howmany {
+		span(d.N, stride(d))
+	}
+	if lo < 0 || hi >= length {
+		panic(fmt.Sprintf("fftw: %s elements %d to %d are out of bounds for length %d", name, lo, hi, length))
+	}
+}
+
+func widenIODims(dims []IODim) []IODim64 {
+	wide := make([]IODim64, len(dims))
+	for i, d := range dims {
+		wide[i] = IODim64(d)
+	}
+	return wide
+}
+
+func cIODims(dims []IODim) []C.fftw_iodim {
+	c := make([]C.fftw_iodim, len(dims))
+	for i, d := range dims {
+		for _, x := range [...]int{d.N, d.Is, d.Os} {
+			if x < math.MinInt32 || x > math.MaxInt32 {
+				panic("fftw: guru dimensions must fit in 32 bits; use the guru64 interface")
+			}
+		}
+		c[i] = C.fftw_iodim{n: C.int(d.N), is: C.int(d.Is), os: C.int(d.Os)}
+	}
+	return c
+}
+
+func cIODims64(dims []IODim64) []C.fftw_iodim64 {
+	c := make([]C.fftw_iodim64, len(dims))
+	for i, d := range dims {
+		c[i] = C.fftw_iodim64{n: C.ptrdiff_t(d.N), is: C.ptrdiff_t(d.Is), os: C.ptrdiff_t(d.Os)}
+	}
+	return c
+}
+
+func cKinds(kinds []Kind, dims []IODim64) []C.fftw_r2r_kind {
+	if len(kinds) != len(dims) {
+		panic("fftw: need one kind per dimension")
+	}
+	c := make([]C.fftw_r2r_kind, len(kinds))
+	for i, k := range kinds {
+		checkKind(k, dims[i].N)
+		c[i] = C.fftw_r2r_kind(k)
+	}
+	return c
+}
+
+func firstIODim(x []C.fftw_iodim) *C.fftw_iodim {
+	if len(x) == 0 {
+		return nil
+	}
+	return &x[0]
+}
+
+func firstIODim64(x []C.fftw_iodim64) *C.fftw_iodim64 {
+	if len(x) == 0 {
+		return nil
+	}
+	return &x[0]
+}
+
+func firstKind(x []C.fftw_r2r_kind) *C.fftw_r2r_kind {
+	if len(x) == 0 {
+		return nil
+	}
+	return &x[0]
+}
@@ -0,0 +1,91 @@
+//go:build !cgo || purego
+
+package fftw
+
+// NewPlanGuru panics with ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func NewPlanGuru(dims, howmany []IODim, in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru(dims, howmany, in, out, dir, flag))
+}
+
+// TryNewPlanGuru returns ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func TryNewPlanGuru(dims, howmany []IODim, in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuru64 is the version of NewPlanGuru with 64-bit sizes and strides.
+func NewPlanGuru64(dims, howmany []IODim64, in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64(dims, howmany, in, out, dir, flag))
+}
+
+// TryNewPlanGuru64 is the version of TryNewPlanGuru with 64-bit sizes and strides.
+func TryNewPlanGuru64(dims, howmany []IODim64, in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuruR2C panics with ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func NewPlanGuruR2C(dims, howmany []IODim, in *RealArray, out *Array, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruR2C(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuruR2C returns ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func TryNewPlanGuruR2C(dims, howmany []IODim, in *RealArray, out *Array, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuru64R2C is the version of NewPlanGuruR2C with 64-bit sizes and strides.
+func NewPlanGuru64R2C(dims, howmany []IODim64, in *RealArray, out *Array, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64R2C(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuru64R2C is the version of TryNewPlanGuruR2C with 64-bit sizes and strides.
+func TryNewPlanGuru64R2C(dims, howmany []IODim64, in *RealArray, out *Array, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuruC2R panics with ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func NewPlanGuruC2R(dims, howmany []IODim, in *Array, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruC2R(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuruC2R returns ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func TryNewPlanGuruC2R(dims, howmany []IODim, in *Array, out *RealArray, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuru64C2R is the version of NewPlanGuruC2R with 64-bit sizes and strides.
+func NewPlanGuru64C2R(dims, howmany []IODim64, in *Array, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64C2R(dims, howmany, in, out, flag))
+}
+
+// TryNewPlanGuru64C2R is the version of TryNewPlanGuruC2R with 64-bit sizes and strides.
+func TryNewPlanGuru64C2R(dims, howmany []IODim64, in *Array, out *RealArray, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuruR2R panics with ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func NewPlanGuruR2R(dims, howmany []IODim, in, out *RealArray, kinds []Kind, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuruR2R(dims, howmany, in, out, kinds, flag))
+}
+
+// TryNewPlanGuruR2R returns ErrNoPlan, because the pure-Go backend has no guru
+// interface.
+func TryNewPlanGuruR2R(dims, howmany []IODim, in, out *RealArray, kinds []Kind, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanGuru64R2R is the version of NewPlanGuruR2R with 64-bit sizes and strides.
+func NewPlanGuru64R2R(dims, howmany []IODim64, in, out *RealArray, kinds []Kind, flag Flag) *Plan {
+	return mustPlan(TryNewPlanGuru64R2R(dims, howmany, in, out, kinds, flag))
+}
+
+// TryNewPlanGuru64R2R is the version of TryNewPlanGuruR2R with 64-bit sizes and strides.
+func TryNewPlanGuru64R2R(dims, howmany []IODim64, in, out *RealArray, kinds []Kind, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
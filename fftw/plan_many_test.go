@@ -0,0 +1,191 @@
+package fftw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPlanManyGuards(t *testing.T) {
+	t.Parallel()
+
+	var nilBatch *BatchArray
+
+	expectPanic(t, "nil input", func() {
+		NewPlanMany(nilBatch, NewBatchArray([]int{4}, 2), Forward, Estimate)
+	})
+
+	expectPanic(t, "empty batch", func() {
+		NewPlanMany(NewBatchArray([]int{4}, 0), NewBatchArray([]int{4}, 0), Forward, Estimate)
+	})
+
+	expectPanic(t, "howmany mismatch", func() {
+		NewPlanMany(NewBatchArray([]int{4}, 2), NewBatchArray([]int{4}, 3), Forward, Estimate)
+	})
+
+	expectPanic(t, "out of bounds", func() {
+		in := NewBatchArray([]int{4}, 2)
+		in.Dist = 5
+
+		NewPlanMany(in, NewBatchArray([]int{4}, 2), Forward, Estimate)
+	})
+
+	expectPanic(t, "stride out of bounds", func() {
+		in := NewBatchArray([]int{4}, 2)
+		in.Stride = 2
+
+		NewPlanMany(in, NewBatchArray([]int{4}, 2), Forward, Estimate)
+	})
+
+	expectPanic(t, "small embedding", func() {
+		in := NewBatchArray([]int{4, 4}, 1)
+		in.Embed = []int{4, 3}
+
+		NewPlanMany(in, NewBatchArray([]int{4, 4}, 1), Forward, Estimate)
+	})
+
+	expectPanic(t, "r2c output dims", func() {
+		NewPlanManyR2C(NewRealBatchArray([]int{8}, 2), NewBatchArray([]int{8}, 2), Estimate)
+	})
+
+	expectPanic(t, "r2r kinds", func() {
+		NewPlanManyR2R(NewRealBatchArray([]int{8}, 2), NewRealBatchArray([]int{8}, 2), nil, Estimate)
+	})
+}
+
+func TestNewPlanManyContiguous(t *testing.T) {
+	t.Parallel()
+
+	const n, howmany = 16, 8
+
+	in := NewBatchArray([]int{n}, howmany)
+	out := NewBatchArray([]int{n}, howmany)
+
+	p := NewPlanMany(in, out, Forward, Estimate)
+	defer p.Destroy()
+
+	for i := range in.Elems {
+		in.Elems[i] = complex(float64(i%7), float64(i%3))
+	}
+
+	p.Execute()
+
+	for b := range howmany {
+		want := FFT(&Array{in.Elems[b*n : (b+1)*n]})
+		for i := range n {
+			got := out.At(b, []int{i})
+			testAlmostEqual(t, real(got), real(want.Elems[i]))
+			testAlmostEqual(t, imag(got), imag(want.Elems[i]))
+		}
+	}
+}
+
+func TestNewPlanManyColumns(t *testing.T) {
+	t.Parallel()
+
+	const rows, cols = 12, 5
+
+	m := NewArray2(rows, cols)
+	for i := range m.Elems {
+		m.Elems[i] = complex(math.Sin(float64(i)), 0)
+	}
+
+	// Transform each column of m in place.
+	col := &BatchArray{N: []int{rows}, HowMany: cols, Stride: cols, Dist: 1, Elems: m.Elems}
+
+	want := make([]*Array, cols)
+	for j := range cols {
+		c := NewArray(rows)
+		for i := range rows {
+			c.Elems[i] = m.At(i, j)
+		}
+
+		want[j] = FFT(c)
+	}
+
+	NewPlanMany(col, col, Forward, Estimate).Execute().Destroy()
+
+	for j := range cols {
+		for i := range rows {
+			testAlmostEqual(t, real(m.At(i, j)), real(want[j].Elems[i]))
+			testAlmostEqual(t, imag(m.At(i, j)), imag(want[j].Elems[i]))
+		}
+	}
+}
+
+func TestNewPlanManyEmbedded(t *testing.T) {
+	t.Parallel()
+
+	// Two 3x4 transforms, each stored in the top-left corner of a 3x6 block.
+	n := []int{3, 4}
+	in := &BatchArray{N: n, HowMany: 2, Stride: 1, Dist: 18, Embed: []int{3, 6}, Elems: make([]complex128, 36)}
+	out := NewBatchArray(n, 2)
+
+	for b := range 2 {
+		for i := range 3 {
+			for j := range 4 {
+				in.Set(b, []int{i, j}, complex(float64(b+i*j), float64(j-i)))
+			}
+		}
+	}
+
+	NewPlanMany(in, out, Backward, Estimate).Execute().Destroy()
+
+	for b := range 2 {
+		src := NewArray2(3, 4)
+		for i := range 3 {
+			for j := range 4 {
+				src.Set(i, j, in.At(b, []int{i, j}))
+			}
+		}
+
+		want := IFFT2(src)
+		for i := range 3 {
+			for j := range 4 {
+				got := out.At(b, []int{i, j})
+				testAlmostEqual(t, real(got), real(want.At(i, j)))
+				testAlmostEqual(t, imag(got), imag(want.At(i, j)))
+			}
+		}
+	}
+}
+
+func TestNewPlanManyReal(t *testing.T) {
+	t.Parallel()
+
+	const n, howmany = 10, 4
+
+	in := NewRealBatchArray([]int{n}, howmany)
+	spec := NewBatchArray([]int{n/2 + 1}, howmany)
+	back := NewRealBatchArray([]int{n}, howmany)
+
+	for i := range in.Elems {
+		in.Elems[i] = float64(i%6) - 2
+	}
+
+	NewPlanManyR2C(in, spec, Estimate).Execute().Destroy()
+
+	for b := range howmany {
+		want := RFFT(&RealArray{in.Elems[b*n : (b+1)*n]})
+		for i := range n/2 + 1 {
+			got := spec.At(b, []int{i})
+			testAlmostEqual(t, real(got), real(want.Elems[i]))
+			testAlmostEqual(t, imag(got), imag(want.Elems[i]))
+		}
+	}
+
+	NewPlanManyC2R(spec, back, Estimate).Execute().Destroy()
+
+	for i := range in.Elems {
+		testAlmostEqual(t, back.Elems[i], n*in.Elems[i])
+	}
+
+	dct := NewRealBatchArray([]int{n}, howmany)
+	NewPlanManyR2R(in, dct, []Kind{REDFT10}, Estimate).Execute().Destroy()
+
+	for b := range howmany {
+		want := DCT(&RealArray{in.Elems[b*n : (b+1)*n]})
+		for i := range n {
+			testAlmostEqual(t, dct.At(b, []int{i}), want.Elems[i])
+		}
+	}
+}
@@ -0,0 +1,278 @@
+//go:build !cgo || purego
+
+package fftw
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// NewPlanR2R returns a plan for the real-to-real transform of the given kind.
+//
+// Real-to-real transforms are their own kind of plan, with no direction: the
+// inverse of each kind is another kind, up to a scale factor.
+func NewPlanR2R(in, out *RealArray, kind Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	if in.Len() == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in.Len() != out.Len() {
+		panic("fftw: input and output lengths must match")
+	}
+	checkKind(kind, in.Len())
+	return mustPlan(newR2RPlan([]int{in.Len()}, []Kind{kind}, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2R2 returns a plan for the 2D real-to-real transform that applies
+// kind0 along the first dimension and kind1 along the second.
+func NewPlanR2R2(in, out *RealArray2, kind0, kind1 Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if in0 <= 0 || in1 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 {
+		panic("fftw: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw: real-to-real transforms do not support padded arrays")
+	}
+	checkKind(kind0, in0)
+	checkKind(kind1, in1)
+	return mustPlan(newR2RPlan(in.N[:], []Kind{kind0, kind1}, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2R3 returns a plan for the 3D real-to-real transform that applies
+// kind0, kind1 and kind2 along the respective dimensions.
+func NewPlanR2R3(in, out *RealArray3, kind0, kind1, kind2 Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 || in2 != out2 {
+		panic("fftw: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw: real-to-real transforms do not support padded arrays")
+	}
+	checkKind(kind0, in0)
+	checkKind(kind1, in1)
+	checkKind(kind2, in2)
+	return mustPlan(newR2RPlan(in.N[:], []Kind{kind0, kind1, kind2}, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2RN returns a plan for the N-dimensional real-to-real transform that
+// applies kinds[i] along dimension i.
+func NewPlanR2RN(in, out *RealArrayN, kinds []Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	inDims := in.Dims()
+	if len(inDims) == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	for i := range inDims {
+		if inDims[i] <= 0 {
+			panic("fftw: input and output must be non-empty")
+		}
+	}
+	if !equalDims(inDims, out.Dims()) {
+		panic("fftw: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw: real-to-real transforms do not support padded arrays")
+	}
+	if len(kinds) != len(inDims) {
+		panic("fftw: need one kind per dimension")
+	}
+	for i, k := range kinds {
+		checkKind(k, inDims[i])
+	}
+	return mustPlan(newR2RPlan(inDims, kinds, in.Elems, out.Elems, flag))
+}
+
+// newR2RPlan returns a plan for the real-to-real transform of the arrays in and
+// out with dimensions dims, applying kinds[i] along dimension i.
+func newR2RPlan(dims []int, kinds []Kind, in, out []float64, flag Flag) (*Plan, error) {
+	axes := make([]*r2r1, len(dims))
+	size := prod(dims)
+	longest, scratch, adds, muls := 0, 0, 0.0, 0.0
+	desc := make([]string, len(dims))
+	for i, n := range dims {
+		axes[i] = newR2R1(kinds[i], n)
+		longest = max(longest, n)
+		scratch = max(scratch, axes[i].scratchLen())
+		lines := float64(size / n)
+		adds += lines * axes[i].dft.adds
+		muls += lines * axes[i].dft.muls
+		desc[i] = fmt.Sprintf("(%v %v)", kinds[i], axes[i].dft)
+	}
+	pool := sync.Pool{New: func() any {
+		return &r2rScratch{make([]float64, 2*longest), make([]complex128, scratch)}
+	}}
+
+	plan, err := newPlan(flag, r2rPlan, shape(dims, false), shape(dims, false),
+		unsafe.Pointer(unsafe.SliceData(in)), unsafe.Pointer(unsafe.SliceData(out)))
+	if err != nil {
+		return nil, err
+	}
+	plan.run = func(in, out unsafe.Pointer) {
+		s := pool.Get().(*r2rScratch)
+		defer pool.Put(s)
+		x := unsafe.Slice((*float64)(out), size)
+		if in != out {
+			copy(x, unsafe.Slice((*float64)(in), size))
+		}
+		stride := 1
+		for axis := len(dims) - 1; axis >= 0; axis-- {
+			n := dims[axis]
+			line, res := s.line[:n], s.line[n:2*n]
+			for base := 0; base < size; base += n * stride {
+				for i := base; i < base+stride; i++ {
+					for k := range n {
+						line[k] = x[i+k*stride]
+					}
+					axes[axis].transform(res, line, s.z)
+					for k := range n {
+						x[i+k*stride] = res[k]
+					}
+				}
+			}
+			stride *= n
+		}
+	}
+	plan.desc = fmt.Sprintf("(r2r %v %s)", dims, strings.Join(desc, " "))
+	plan.adds, plan.muls = adds, muls
+	return plan, nil
+}
+
+// r2rScratch is the scratch space of the execution of a real-to-real plan.
+type r2rScratch struct {
+	line []float64
+	z    []complex128
+}
+
+// r2r1 computes one-dimensional real-to-real transforms of length n through a
+// complex DFT.
+//
+// The halfcomplex kinds use a DFT of length n. The others are written as
+// Y_k = sum c_j X_j cos or sin(2π(2j+a)(2k+b)/M) with M = 8n', n' being n-1,
+// n or n+1, which is the real or imaginary part of a DFT of length M whose
+// input is c_j X_j at index 2j+a and zero elsewhere.
+type r2r1 struct {
+	kind Kind
+	n    int
+	dft  *dft1
+	// The offsets of the input and output indices of the trigonometric kinds.
+	a, b int
+	// The weights c_j, which are 2 except at the ends of some kinds.
+	first, last float64
+}
+
+func newR2R1(kind Kind, n int) *r2r1 {
+	t := &r2r1{kind: kind, n: n, dft: nil, a: 0, b: 0, first: 2, last: 2}
+	size := 8 * n
+	switch kind {
+	case R2HC, DHT:
+		t.dft = newDFT1(n, Forward)
+		return t
+	case HC2R:
+		t.dft = newDFT1(n, Backward)
+		return t
+	case REDFT00:
+		size = 8 * (n - 1)
+		t.first, t.last = 1, 1
+	case REDFT10:
+		t.a = 1
+	case REDFT01:
+		t.b = 1
+		t.first = 1
+	case REDFT11, RODFT11:
+		t.a, t.b = 1, 1
+	case RODFT00:
+		size = 8 * (n + 1)
+		t.a, t.b = 2, 2
+	case RODFT10:
+		t.a, t.b = 1, 2
+	case RODFT01:
+		t.a, t.b = 2, 1
+		t.last = 1
+	}
+	t.dft = newDFT1(size, Forward)
+	return t
+}
+
+// scratchLen returns the length of the scratch space transform needs.
+func (t *r2r1) scratchLen() int {
+	return 2*t.dft.n + t.dft.scratchLen()
+}
+
+// transform computes the transform of src into dst.
+func (t *r2r1) transform(dst, src []float64, scratch []complex128) {
+	n, m := t.n, t.dft.n
+	in, out := scratch[:m], scratch[m:2*m]
+	switch t.kind {
+	case R2HC, DHT:
+		for j := range n {
+			in[j] = complex(src[j], 0)
+		}
+		t.dft.transform(out, in, scratch[2*m:])
+		if t.kind == DHT {
+			for k := range n {
+				dst[k] = real(out[k]) - imag(out[k])
+			}
+			return
+		}
+		for k := 0; k <= n/2; k++ {
+			dst[k] = real(out[k])
+		}
+		for k := 1; k <= (n-1)/2; k++ {
+			dst[n-k] = imag(out[k])
+		}
+		return
+	case HC2R:
+		in[0] = complex(src[0], 0)
+		for k := 1; k <= (n-1)/2; k++ {
+			in[k] = complex(src[k], src[n-k])
+			in[n-k] = conj(in[k])
+		}
+		if n%2 == 0 {
+			in[n/2] = complex(src[n/2], 0)
+		}
+		t.dft.transform(out, in, scratch[2*m:])
+		for k := range n {
+			dst[k] = real(out[k])
+		}
+		return
+	}
+
+	clear(in)
+	for j := range n {
+		in[2*j+t.a] = complex(2*src[j], 0)
+	}
+	in[t.a] = complex(t.first*src[0], 0)
+	if n > 1 {
+		in[2*(n-1)+t.a] = complex(t.last*src[n-1], 0)
+	} else {
+		in[t.a] = complex(min(t.first, t.last)*src[0], 0)
+	}
+	t.dft.transform(out, in, scratch[2*m:])
+	for k := range n {
+		if t.kind <= REDFT11 {
+			dst[k] = real(out[2*k+t.b])
+		} else {
+			dst[k] = -imag(out[2*k+t.b])
+		}
+	}
+}
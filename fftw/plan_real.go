@@ -0,0 +1,368 @@
+//go:build cgo && !purego
+
+package fftw
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewPlanR2C returns a plan for the forward transform of the real signal in.
+//
+// A real signal of length n has a Hermitian spectrum, so only its n/2+1
+// non-negative frequency terms are computed; out must have that length.
+func NewPlanR2C(in *RealArray, out *Array, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	if in.Len() == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if out.Len() != in.Len()/2+1 {
+		panic("fftw: output length must be n/2+1")
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		numElems = C.int(in.Len())
+		inPtr    = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr   = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_    = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(in.Len(), Forward, out.Elems)
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_dft_r2c_1d(numElems, inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanC2R returns a plan for the backward transform of the n/2+1 element
+// Hermitian half-spectrum in to the real signal out of length n.
+//
+// Beware that FFTW overwrites the input of a complex-to-real transform when the
+// plan is executed.
+func NewPlanC2R(in *Array, out *RealArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	if out.Len() == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in.Len() != out.Len()/2+1 {
+		panic("fftw: input length must be n/2+1")
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		numElems = C.int(out.Len())
+		inPtr    = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr   = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_    = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = realScaling(out.Len(), out.Elems)
+	lockPlanner(flag)
+	plan.fftwP = C.fftw_plan_dft_c2r_1d(numElems, inPtr, outPtr, flag_)
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2C2 returns a plan for the forward transform of the n0 x n1 real array in.
+//
+// The output holds the n0 x (n1/2+1) non-redundant elements of the spectrum.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2C2(in *RealArray2, out *Array2, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if in0 <= 0 || in1 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if out0 != in0 || out1 != in1/2+1 {
+		panic("fftw: output dimensions must be n0 x (n1/2+1)")
+	}
+	inPlace := realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(in0)
+		dim1   = C.int(in1)
+		inPtr  = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape(in.N[:], in.Padded), shape(out.N[:], false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(in.N[:]), Forward, out.Elems)
+	lockPlanner(flag)
+	if in.Padded && !inPlace {
+		plan.fftwP = planPaddedR2C(in.N[:], inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_r2c_2d(dim0, dim1, inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanC2R2 returns a plan for the backward transform of the n0 x (n1/2+1)
+// half-spectrum in to the n0 x n1 real array out.
+//
+// A padded output may be transformed in place, using out.Complex() as the input.
+// Beware that FFTW overwrites the input of a multi-dimensional complex-to-real
+// transform when the plan is executed.
+func NewPlanC2R2(in *Array2, out *RealArray2, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if out0 <= 0 || out1 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1/2+1 {
+		panic("fftw: input dimensions must be n0 x (n1/2+1)")
+	}
+	inPlace := realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(out0)
+		dim1   = C.int(out1)
+		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape(in.N[:], false), shape(out.N[:], out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = realScaling(prod(out.N[:]), out.Elems)
+	lockPlanner(flag)
+	if out.Padded && !inPlace {
+		plan.fftwP = planPaddedC2R(out.N[:], inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_c2r_2d(dim0, dim1, inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2C3 returns a plan for the forward transform of the n0 x n1 x n2 real array in.
+//
+// The output holds the n0 x n1 x (n2/2+1) non-redundant elements of the spectrum.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2C3(in *RealArray3, out *Array3, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if out0 != in0 || out1 != in1 || out2 != in2/2+1 {
+		panic("fftw: output dimensions must be n0 x n1 x (n2/2+1)")
+	}
+	inPlace := realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(in0)
+		dim1   = C.int(in1)
+		dim2   = C.int(in2)
+		inPtr  = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape(in.N[:], in.Padded), shape(out.N[:], false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(in.N[:]), Forward, out.Elems)
+	lockPlanner(flag)
+	if in.Padded && !inPlace {
+		plan.fftwP = planPaddedR2C(in.N[:], inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_r2c_3d(dim0, dim1, dim2, inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanC2R3 returns a plan for the backward transform of the n0 x n1 x (n2/2+1)
+// half-spectrum in to the n0 x n1 x n2 real array out.
+//
+// A padded output may be transformed in place, using out.Complex() as the input.
+// Beware that FFTW overwrites the input of a multi-dimensional complex-to-real
+// transform when the plan is executed.
+func NewPlanC2R3(in *Array3, out *RealArray3, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if out0 <= 0 || out1 <= 0 || out2 <= 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 || in2 != out2/2+1 {
+		panic("fftw: input dimensions must be n0 x n1 x (n2/2+1)")
+	}
+	inPlace := realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(out0)
+		dim1   = C.int(out1)
+		dim2   = C.int(out2)
+		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape(in.N[:], false), shape(out.N[:], out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = realScaling(prod(out.N[:]), out.Elems)
+	lockPlanner(flag)
+	if out.Padded && !inPlace {
+		plan.fftwP = planPaddedC2R(out.N[:], inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_c2r_3d(dim0, dim1, dim2, inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2CN returns a plan for the forward transform of the real array in.
+//
+// The output has the dimensions of in, except that the last one is n/2+1.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2CN(in *RealArrayN, out *ArrayN, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	inDims := in.Dims()
+	if len(inDims) == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	for i := range inDims {
+		if inDims[i] <= 0 {
+			panic("fftw: input and output must be non-empty")
+		}
+	}
+	if !equalDims(out.Dims(), halfDims(inDims)) {
+		panic("fftw: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	inPlace := realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(inDims)
+	var (
+		rank   = C.int(len(inDims))
+		inPtr  = (*C.double)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.fftw_complex)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2cPlan, shape(in.N, in.Padded), shape(out.N, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = complexScaling(prod(in.N), Forward, out.Elems)
+	lockPlanner(flag)
+	if in.Padded && !inPlace {
+		plan.fftwP = planPaddedR2C(inDims, inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_r2c(rank, &numElems[0], inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// NewPlanC2RN returns a plan for the backward transform of the half-spectrum in
+// to the real array out.
+//
+// The input has the dimensions of out, except that the last one is n/2+1.
+// A padded output may be transformed in place, using out.Complex() as the input.
+// Beware that FFTW overwrites the input of a multi-dimensional complex-to-real
+// transform when the plan is executed.
+func NewPlanC2RN(in *ArrayN, out *RealArrayN, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw: input and output must be non-nil")
+	}
+	outDims := out.Dims()
+	if len(outDims) == 0 {
+		panic("fftw: input and output must be non-empty")
+	}
+	for i := range outDims {
+		if outDims[i] <= 0 {
+			panic("fftw: input and output must be non-empty")
+		}
+	}
+	if !equalDims(in.Dims(), halfDims(outDims)) {
+		panic("fftw: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	inPlace := realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(outDims)
+	var (
+		rank   = C.int(len(outDims))
+		inPtr  = (*C.fftw_complex)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.double)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, c2rPlan, shape(in.N, false), shape(out.N, out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	plan.scaling = realScaling(prod(out.N), out.Elems)
+	lockPlanner(flag)
+	if out.Padded && !inPlace {
+		plan.fftwP = planPaddedC2R(outDims, inPtr, outPtr, flag_)
+	} else {
+		plan.fftwP = C.fftw_plan_dft_c2r(rank, &numElems[0], inPtr, outPtr, flag_)
+	}
+	unlockPlanner(flag)
+
+	return mustPlan(plan.finish())
+}
+
+// planPaddedR2C plans an out-of-place transform from a padded real array, whose
+// layout is described to FFTW as an embedding with a longer last dimension.
+// The caller must hold createDestroyMu.
+func planPaddedR2C(n []int, in *C.double, out *C.fftw_complex, flag C.uint) C.fftw_plan {
+	dims := cInts(n)
+	embed := cInts(n)
+	embed[len(n)-1] = C.int(rowLen(n[len(n)-1], true))
+	return C.fftw_plan_many_dft_r2c(C.int(len(n)), &dims[0], 1, in, &embed[0], 1, 0, out, nil, 1, 0, flag)
+}
+
+// planPaddedC2R plans an out-of-place transform to a padded real array.
+// The caller must hold createDestroyMu.
+func planPaddedC2R(n []int, in *C.fftw_complex, out *C.double, flag C.uint) C.fftw_plan {
+	dims := cInts(n)
+	embed := cInts(n)
+	embed[len(n)-1] = C.int(rowLen(n[len(n)-1], true))
+	return C.fftw_plan_many_dft_c2r(C.int(len(n)), &dims[0], 1, in, nil, 1, 0, out, &embed[0], 1, 0, flag)
+}
+
+func cInts(x []int) []C.int {
+	c := make([]C.int, len(x))
+	for i := range x {
+		c[i] = C.int(x[i])
+	}
+	return c
+}
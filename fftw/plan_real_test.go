@@ -0,0 +1,335 @@
+package fftw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPlanR2CGuards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilReal    *RealArray
+		nilComplex *Array
+	)
+
+	expectPanic(t, "nil input", func() {
+		NewPlanR2C(nilReal, NewArray(1), Estimate)
+	})
+
+	expectPanic(t, "nil output", func() {
+		NewPlanR2C(NewRealArray(1), nilComplex, Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanR2C(NewRealArray(0), NewArray(1), Estimate)
+	})
+
+	expectPanic(t, "output length", func() {
+		NewPlanR2C(NewRealArray(8), NewArray(8), Estimate)
+	})
+}
+
+func TestNewPlanC2RGuards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilReal    *RealArray
+		nilComplex *Array
+	)
+
+	expectPanic(t, "nil input", func() {
+		NewPlanC2R(nilComplex, NewRealArray(1), Estimate)
+	})
+
+	expectPanic(t, "nil output", func() {
+		NewPlanC2R(NewArray(1), nilReal, Estimate)
+	})
+
+	expectPanic(t, "empty output", func() {
+		NewPlanC2R(NewArray(1), NewRealArray(0), Estimate)
+	})
+
+	expectPanic(t, "input length", func() {
+		NewPlanC2R(NewArray(4), NewRealArray(9), Estimate)
+	})
+}
+
+func TestRFFTMatchesFFT(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1, 2, 7, 16, 33} {
+		src := NewRealArray(n)
+		full := NewArray(n)
+
+		for i := range src.Elems {
+			src.Elems[i] = math.Sin(float64(i*i)) + 0.5
+			full.Elems[i] = complex(src.Elems[i], 0)
+		}
+
+		half := RFFT(src)
+		if half.Len() != n/2+1 {
+			t.Fatalf("n=%d: expected %d elements, got %d", n, n/2+1, half.Len())
+		}
+
+		want := FFT(full)
+		for i := range half.Elems {
+			testAlmostEqual(t, real(half.Elems[i]), real(want.Elems[i]))
+			testAlmostEqual(t, imag(half.Elems[i]), imag(want.Elems[i]))
+		}
+	}
+}
+
+func TestRFFTAndIRFFT(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1, 6, 15, 32} {
+		src := NewRealArray(n)
+		for i := range src.Elems {
+			src.Elems[i] = float64(i%5) - 1.5
+		}
+
+		spec := RFFT(src)
+		saved := append([]complex128(nil), spec.Elems...)
+
+		back := IRFFT(spec, n)
+		for i := range src.Elems {
+			testAlmostEqual(t, back.Elems[i], float64(n)*src.Elems[i])
+		}
+
+		// The helper must not destroy its input.
+		for i := range saved {
+			if spec.Elems[i] != saved[i] {
+				t.Fatalf("n=%d: IRFFT modified its input at %d", n, i)
+			}
+		}
+
+		dst := NewArray(n/2 + 1)
+		RFFTTo(dst, src)
+
+		out := NewRealArray(n)
+		IRFFTTo(out, dst)
+
+		for i := range src.Elems {
+			testAlmostEqual(t, out.Elems[i], float64(n)*src.Elems[i])
+		}
+	}
+}
+
+func TestNewPlanR2CCosine(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	in := NewRealArray(n)
+	out := NewArray(n/2 + 1)
+
+	p := NewPlanR2C(in, out, Estimate)
+	defer p.Destroy()
+
+	for i := range in.Elems {
+		in.Elems[i] = math.Cos(float64(i) / n * math.Pi * 2)
+	}
+
+	p.Execute()
+
+	for i := range out.Elems {
+		want := 0.0
+		if i == 1 {
+			want = n / 2
+		}
+
+		testAlmostEqual(t, real(out.Elems[i]), want)
+		testAlmostEqual(t, imag(out.Elems[i]), 0.0)
+	}
+}
+
+func TestNewPlanR2C2Guards(t *testing.T) {
+	t.Parallel()
+
+	var nilReal *RealArray2
+
+	expectPanic(t, "nil input", func() {
+		NewPlanR2C2(nilReal, NewArray2(1, 1), Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanR2C2(NewRealArray2(0, 4), NewArray2(0, 3), Estimate)
+	})
+
+	expectPanic(t, "output dims", func() {
+		NewPlanR2C2(NewRealArray2(4, 6), NewArray2(4, 6), Estimate)
+	})
+
+	padded := NewRealArray2Padded(4, 6)
+	unpadded := &RealArray2{N: padded.N, Elems: padded.Elems}
+
+	expectPanic(t, "unpadded in place", func() {
+		NewPlanR2C2(unpadded, padded.Complex(), Estimate)
+	})
+
+	expectPanic(t, "complex view of unpadded", func() {
+		NewRealArray2(4, 6).Complex()
+	})
+}
+
+func TestNewPlanC2RNGuards(t *testing.T) {
+	t.Parallel()
+
+	expectPanic(t, "empty dims", func() {
+		NewPlanC2RN(NewArrayN([]int{}), NewRealArrayN([]int{}), Estimate)
+	})
+
+	expectPanic(t, "input dims", func() {
+		NewPlanC2RN(NewArrayN([]int{2, 3, 4}), NewRealArrayN([]int{2, 3, 4}), Estimate)
+	})
+}
+
+func TestRFFT2MatchesFFT2(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 6, 5
+
+	src := NewRealArray2(n0, n1)
+	full := NewArray2(n0, n1)
+
+	for i := range n0 {
+		for j := range n1 {
+			src.Set(i, j, math.Cos(float64(3*i+j*j)))
+			full.Set(i, j, complex(src.At(i, j), 0))
+		}
+	}
+
+	half := RFFT2(src)
+	want := FFT2(full)
+
+	if d0, d1 := half.Dims(); d0 != n0 || d1 != n1/2+1 {
+		t.Fatalf("expected dims (%d,%d), got (%d,%d)", n0, n1/2+1, d0, d1)
+	}
+
+	for i := range n0 {
+		for j := range n1/2 + 1 {
+			testAlmostEqual(t, real(half.At(i, j)), real(want.At(i, j)))
+			testAlmostEqual(t, imag(half.At(i, j)), imag(want.At(i, j)))
+		}
+	}
+
+	back := IRFFT2(half, n1)
+	for i := range n0 {
+		for j := range n1 {
+			testAlmostEqual(t, back.At(i, j), n0*n1*src.At(i, j))
+		}
+	}
+}
+
+func TestRFFT2InPlacePadded(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	a := NewRealArray2Padded(n0, n1)
+	ref := NewRealArray2(n0, n1)
+
+	for i := range n0 {
+		for j := range n1 {
+			a.Set(i, j, float64(i*n1+j))
+			ref.Set(i, j, float64(i*n1+j))
+		}
+	}
+
+	// Out of place from a padded array.
+	want := RFFT2(ref)
+	got := RFFT2(a)
+
+	for i := range want.Elems {
+		testAlmostEqual(t, real(got.Elems[i]), real(want.Elems[i]))
+		testAlmostEqual(t, imag(got.Elems[i]), imag(want.Elems[i]))
+	}
+
+	forward := NewPlanR2C2(a, a.Complex(), Estimate)
+	defer forward.Destroy()
+
+	backward := NewPlanC2R2(a.Complex(), a, Estimate)
+	defer backward.Destroy()
+
+	forward.Execute()
+
+	spec := a.Complex()
+	for i := range want.Elems {
+		testAlmostEqual(t, real(spec.Elems[i]), real(want.Elems[i]))
+		testAlmostEqual(t, imag(spec.Elems[i]), imag(want.Elems[i]))
+	}
+
+	backward.Execute()
+
+	rows := a.Slice()
+	for i := range n0 {
+		if len(rows[i]) != n1 {
+			t.Fatalf("expected rows of %d elements, got %d", n1, len(rows[i]))
+		}
+
+		for j := range n1 {
+			testAlmostEqual(t, rows[i][j], n0*n1*ref.At(i, j))
+		}
+	}
+}
+
+func TestRFFT3AndIRFFT3(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1, n2 = 3, 4, 5
+
+	src := NewRealArray3(n0, n1, n2)
+	for i := range src.Elems {
+		src.Elems[i] = math.Sin(float64(i))
+	}
+
+	spec := RFFT3(src)
+	if d0, d1, d2 := spec.Dims(); d0 != n0 || d1 != n1 || d2 != n2/2+1 {
+		t.Fatalf("expected dims (%d,%d,%d), got (%d,%d,%d)", n0, n1, n2/2+1, d0, d1, d2)
+	}
+
+	back := IRFFT3(spec, n2)
+	for i := range src.Elems {
+		testAlmostEqual(t, back.Elems[i], n0*n1*n2*src.Elems[i])
+	}
+}
+
+func TestRFFTNInPlacePadded(t *testing.T) {
+	t.Parallel()
+
+	dims := []int{2, 3, 4, 3}
+	total := 2 * 3 * 4 * 3
+
+	src := NewRealArrayN(dims)
+	for i := range src.Elems {
+		src.Elems[i] = float64(i%7) - 3
+	}
+
+	want := RFFTN(src)
+
+	a := NewRealArrayNPadded(dims)
+	for i0 := range dims[0] {
+		for i1 := range dims[1] {
+			for i2 := range dims[2] {
+				for i3 := range dims[3] {
+					idx := []int{i0, i1, i2, i3}
+					a.Set(idx, src.At(idx))
+				}
+			}
+		}
+	}
+
+	NewPlanR2CN(a, a.Complex(), Estimate).Execute().Destroy()
+
+	spec := a.Complex()
+	for i := range want.Elems {
+		testAlmostEqual(t, real(spec.Elems[i]), real(want.Elems[i]))
+		testAlmostEqual(t, imag(spec.Elems[i]), imag(want.Elems[i]))
+	}
+
+	back := IRFFTN(want, dims[len(dims)-1])
+	for i := range src.Elems {
+		testAlmostEqual(t, back.Elems[i], float64(total)*src.Elems[i])
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !cgo || purego
+
+package fftw
+
+// NewPlanSplit panics with ErrNoPlan, because the pure-Go backend has no split
+// interface.
+func NewPlanSplit(in, out *SplitArray, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplit(in, out, dir, flag))
+}
+
+// TryNewPlanSplit returns ErrNoPlan, because the pure-Go backend has no split
+// interface.
+func TryNewPlanSplit(in, out *SplitArray, dir Direction, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// 2D version of NewPlanSplit.
+func NewPlanSplit2(in, out *SplitArray2, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplit2(in, out, dir, flag))
+}
+
+// 2D version of TryNewPlanSplit.
+func TryNewPlanSplit2(in, out *SplitArray2, dir Direction, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// N-dimensional version of NewPlanSplit.
+func NewPlanSplitN(in, out *SplitArrayN, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitN(in, out, dir, flag))
+}
+
+// N-dimensional version of TryNewPlanSplit.
+func TryNewPlanSplitN(in, out *SplitArrayN, dir Direction, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanSplitR2C panics with ErrNoPlan, because the pure-Go backend has no
+// split interface.
+func NewPlanSplitR2C(in *RealArray, out *SplitArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2C(in, out, flag))
+}
+
+// TryNewPlanSplitR2C returns ErrNoPlan, because the pure-Go backend has no
+// split interface.
+func TryNewPlanSplitR2C(in *RealArray, out *SplitArray, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// 2D version of NewPlanSplitR2C. The input may be padded.
+func NewPlanSplitR2C2(in *RealArray2, out *SplitArray2, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2C2(in, out, flag))
+}
+
+// 2D version of TryNewPlanSplitR2C. The input may be padded.
+func TryNewPlanSplitR2C2(in *RealArray2, out *SplitArray2, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// N-dimensional version of NewPlanSplitR2C. The input may be padded.
+func NewPlanSplitR2CN(in *RealArrayN, out *SplitArrayN, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2CN(in, out, flag))
+}
+
+// N-dimensional version of TryNewPlanSplitR2C. The input may be padded.
+func TryNewPlanSplitR2CN(in *RealArrayN, out *SplitArrayN, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// NewPlanSplitC2R panics with ErrNoPlan, because the pure-Go backend has no
+// split interface.
+func NewPlanSplitC2R(in *SplitArray, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2R(in, out, flag))
+}
+
+// TryNewPlanSplitC2R returns ErrNoPlan, because the pure-Go backend has no
+// split interface.
+func TryNewPlanSplitC2R(in *SplitArray, out *RealArray, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// 2D version of NewPlanSplitC2R. The output may be padded.
+func NewPlanSplitC2R2(in *SplitArray2, out *RealArray2, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2R2(in, out, flag))
+}
+
+// 2D version of TryNewPlanSplitC2R. The output may be padded.
+func TryNewPlanSplitC2R2(in *SplitArray2, out *RealArray2, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// N-dimensional version of NewPlanSplitC2R. The output may be padded.
+func NewPlanSplitC2RN(in *SplitArrayN, out *RealArrayN, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2RN(in, out, flag))
+}
+
+// N-dimensional version of TryNewPlanSplitC2R. The output may be padded.
+func TryNewPlanSplitC2RN(in *SplitArrayN, out *RealArrayN, flag Flag) (*Plan, error) {
+	return nil, ErrNoPlan
+}
+
+// ExecuteSplit returns ErrPlanKind, because the pure-Go backend has no split
+// plans.
+func (p *Plan) ExecuteSplit(ri, ii, ro, io []float64) error {
+	return ErrPlanKind
+}
+
+// ExecuteSplitR2C returns ErrPlanKind, because the pure-Go backend has no split
+// plans.
+func (p *Plan) ExecuteSplitR2C(in, ro, io []float64) error {
+	return ErrPlanKind
+}
+
+// ExecuteSplitC2R returns ErrPlanKind, because the pure-Go backend has no split
+// plans.
+func (p *Plan) ExecuteSplitC2R(ri, ii, out []float64) error {
+	return ErrPlanKind
+}
@@ -0,0 +1,159 @@
+//go:build cgo && !purego
+
+package fftw
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewPlanSplitGuards(t *testing.T) {
+	t.Parallel()
+
+	var nilSplit *SplitArray
+
+	expectPanic(t, "nil input", func() {
+		NewPlanSplit(nilSplit, NewSplitArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanSplit(NewSplitArray(0), NewSplitArray(0), Forward, Estimate)
+	})
+
+	expectPanic(t, "length mismatch", func() {
+		NewPlanSplit(NewSplitArray(4), NewSplitArray(5), Forward, Estimate)
+	})
+
+	expectPanic(t, "short imaginary part", func() {
+		in := &SplitArray{Re: make([]float64, 4), Im: make([]float64, 3)}
+		NewPlanSplit(in, NewSplitArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "r2c output length", func() {
+		NewPlanSplitR2C(NewRealArray(8), NewSplitArray(8), Estimate)
+	})
+
+	expectPanic(t, "c2r input dims", func() {
+		NewPlanSplitC2R2(NewSplitArray2(4, 6), NewRealArray2(4, 6), Estimate)
+	})
+
+	// There is no wisdom for this unusual size, so FFTW returns a NULL plan.
+	in, out := NewSplitArray(1001), NewSplitArray(1001)
+	if _, err := TryNewPlanSplit(in, out, Forward, Exhaustive|WisdomOnly); !errors.Is(err, ErrNoPlan) {
+		t.Errorf("expected ErrNoPlan, got %v", err)
+	}
+}
+
+func TestNewPlanSplitMatchesNewPlan(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	for _, dir := range []Direction{Forward, Backward} {
+		in := NewSplitArray2(n0, n1)
+		out := NewSplitArray2(n0, n1)
+		ref := NewArray2(n0, n1)
+
+		for i := range n0 {
+			for j := range n1 {
+				x := complex(math.Sin(float64(i*n1+j)), float64(i-j))
+				in.Set(i, j, x)
+				ref.Set(i, j, x)
+			}
+		}
+
+		NewPlanSplit2(in, out, dir, Estimate).Execute().Destroy()
+		NewPlan2(ref, ref, dir, Estimate).Execute().Destroy()
+
+		for i := range n0 {
+			for j := range n1 {
+				testAlmostEqual(t, real(out.At(i, j)), real(ref.At(i, j)))
+				testAlmostEqual(t, imag(out.At(i, j)), imag(ref.At(i, j)))
+			}
+		}
+	}
+}
+
+func TestExecuteSplitOnNewBuffers(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	in := NewSplitArray(n)
+	out := NewSplitArray(n)
+
+	p := NewPlanSplit(in, out, Backward, Estimate)
+	defer p.Destroy()
+
+	// A cosine in planar buffers other than the planned ones.
+	other, res := NewSplitArray(n), NewSplitArray(n)
+	for i := range other.Re {
+		other.Re[i] = math.Cos(float64(i) / n * math.Pi * 2)
+	}
+
+	if err := p.ExecuteSplit(other.Re, other.Im, res.Re, res.Im); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range res.Re {
+		want := 0.0
+		if i == 1 || i == n-1 {
+			want = n / 2
+		}
+
+		testAlmostEqual(t, res.Re[i], want)
+		testAlmostEqual(t, res.Im[i], 0.0)
+	}
+
+	if err := p.ExecuteSplit(other.Re[:n-1], other.Im, res.Re, res.Im); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch, got %v", err)
+	}
+
+	if err := p.ExecuteSplit(other.Re, other.Im, other.Re, other.Im); !errors.Is(err, ErrInPlace) {
+		t.Fatalf("expected ErrInPlace, got %v", err)
+	}
+
+	if err := p.ExecuteSplitR2C(other.Re, res.Re, res.Im); !errors.Is(err, ErrPlanKind) {
+		t.Fatalf("expected ErrPlanKind, got %v", err)
+	}
+}
+
+func TestSplitR2CAndC2R(t *testing.T) {
+	t.Parallel()
+
+	dims := []int{3, 4, 5}
+
+	src := NewRealArrayN(dims)
+	for i := range src.Elems {
+		src.Elems[i] = math.Sin(float64(i)) - 0.25
+	}
+
+	want := RFFTN(src)
+
+	spec := NewSplitArrayN(halfDims(dims))
+	NewPlanSplitR2CN(src, spec, Estimate).Execute().Destroy()
+
+	for i := range want.Elems {
+		testAlmostEqual(t, spec.Re[i], real(want.Elems[i]))
+		testAlmostEqual(t, spec.Im[i], imag(want.Elems[i]))
+	}
+
+	back := NewRealArrayNPadded(dims)
+
+	p := NewPlanSplitC2RN(spec, back, Estimate)
+	defer p.Destroy()
+
+	if err := p.ExecuteSplitC2R(spec.Re, spec.Im, back.Elems); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i0 := range dims[0] {
+		for i1 := range dims[1] {
+			for i2 := range dims[2] {
+				idx := []int{i0, i1, i2}
+				testAlmostEqual(t, back.At(idx), float64(prod(dims))*src.At(idx))
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package fftw
+
+import "fmt"
+
+// FFTShift moves the zero-frequency term of the spectrum src, as computed by
+// FFT or Plan.Execute, to the center: the element at index i moves to
+// (i + n/2) mod n. It allocates memory in which to return the result.
+func FFTShift(src *Array) *Array {
+	dst := NewArray(src.Len())
+	FFTShiftTo(dst, src)
+
+	return dst
+}
+
+// IFFTShift undoes FFTShift, moving the element at index i to (i - n/2) mod n.
+// The two only differ for odd lengths.
+// It allocates memory in which to return the result.
+func IFFTShift(src *Array) *Array {
+	dst := NewArray(src.Len())
+	IFFTShiftTo(dst, src)
+
+	return dst
+}
+
+// FFTShiftTo is the version of FFTShift that returns the result in dst, which
+// may be src to shift in place.
+func FFTShiftTo(dst, src *Array) {
+	shiftTo([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, nil, false)
+}
+
+// IFFTShiftTo is the version of IFFTShift that returns the result in dst,
+// which may be src to shift in place.
+func IFFTShiftTo(dst, src *Array) {
+	shiftTo([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, nil, true)
+}
+
+// 2D version of FFTShift, along the given axes, or all of them if there are none.
+func FFTShift2(src *Array2, axes ...int) *Array2 {
+	dst := NewArray2(src.Dims())
+	FFTShift2To(dst, src, axes...)
+
+	return dst
+}
+
+// 2D version of IFFTShift, along the given axes, or all of them if there are none.
+func IFFTShift2(src *Array2, axes ...int) *Array2 {
+	dst := NewArray2(src.Dims())
+	IFFTShift2To(dst, src, axes...)
+
+	return dst
+}
+
+// 2D version of FFTShiftTo.
+func FFTShift2To(dst, src *Array2, axes ...int) {
+	shiftTo(dst.N[:], src.N[:], dst.Elems, src.Elems, axes, false)
+}
+
+// 2D version of IFFTShiftTo.
+func IFFTShift2To(dst, src *Array2, axes ...int) {
+	shiftTo(dst.N[:], src.N[:], dst.Elems, src.Elems, axes, true)
+}
+
+// 3D version of FFTShift, along the given axes, or all of them if there are none.
+func FFTShift3(src *Array3, axes ...int) *Array3 {
+	dst := NewArray3(src.Dims())
+	FFTShift3To(dst, src, axes...)
+
+	return dst
+}
+
+// 3D version of IFFTShift, along the given axes, or all of them if there are none.
+func IFFTShift3(src *Array3, axes ...int) *Array3 {
+	dst := NewArray3(src.Dims())
+	IFFTShift3To(dst, src, axes...)
+
+	return dst
+}
+
+// 3D version of FFTShiftTo.
+func FFTShift3To(dst, src *Array3, axes ...int) {
+	shiftTo(dst.N[:], src.N[:], dst.Elems, src.Elems, axes, false)
+}
+
+// 3D version of IFFTShiftTo.
+func IFFTShift3To(dst, src *Array3, axes ...int) {
+	shiftTo(dst.N[:], src.N[:], dst.Elems, src.Elems, axes, true)
+}
+
+// N-dimensional version of FFTShift, along the given axes, or all of them if
+// there are none.
+func FFTShiftN(src *ArrayN, axes ...int) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	FFTShiftNTo(dst, src, axes...)
+
+	return dst
+}
+
+// N-dimensional version of IFFTShift, along the given axes, or all of them if
+// there are none.
+func IFFTShiftN(src *ArrayN, axes ...int) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	IFFTShiftNTo(dst, src, axes...)
+
+	return dst
+}
+
+// N-dimensional version of FFTShiftTo.
+func FFTShiftNTo(dst, src *ArrayN, axes ...int) {
+	shiftTo(dst.N, src.N, dst.Elems, src.Elems, axes, false)
+}
+
+// N-dimensional version of IFFTShiftTo.
+func IFFTShiftNTo(dst, src *ArrayN, axes ...int) {
+	shiftTo(dst.N, src.N, dst.Elems, src.Elems, axes, true)
+}
+
+// shiftTo copies src to dst and rotates it along the given axes, or all of them
+// if there are none, by half their length: rounded down for FFTShift and up for
+// IFFTShift.
+func shiftTo(dstDims, srcDims []int, dst, src []complex128, axes []int, inverse bool) {
+	if !equalDims(dstDims, srcDims) {
+		panic(fmt.Sprintf("fftw: input %v and output %v dimensions must match", srcDims, dstDims))
+	}
+	axes = shiftAxes(srcDims, axes)
+	copy(dst, src)
+
+	size := prod(srcDims)
+	for _, axis := range axes {
+		n := srcDims[axis]
+		stride := prod(srcDims[axis+1:])
+		shift := n / 2
+		if inverse {
+			shift = n - shift
+		}
+		if shift == 0 || shift == n {
+			continue
+		}
+		for base := 0; base < size; base += n * stride {
+			for i := base; i < base+stride; i++ {
+				// Rotating right by shift is reversing the line, then both parts.
+				reverse(dst, i, stride, 0, n)
+				reverse(dst, i, stride, 0, shift)
+				reverse(dst, i, stride, shift, n)
+			}
+		}
+	}
+}
+
+// shiftAxes checks the axes of an array with dimensions dims, and returns all of
+// them if there are none.
+func shiftAxes(dims, axes []int) []int {
+	if len(axes) == 0 {
+		axes = make([]int, len(dims))
+		for i := range axes {
+			axes[i] = i
+		}
+		return axes
+	}
+	seen := make([]bool, len(dims))
+	for _, axis := range axes {
+		if axis < 0 || axis >= len(dims) {
+			panic(fmt.Sprintf("fftw: axis %d out of range for %d dimensions", axis, len(dims)))
+		}
+		if seen[axis] {
+			panic(fmt.Sprintf("fftw: duplicate axis %d", axis))
+		}
+		seen[axis] = true
+	}
+	return axes
+}
+
+// reverse reverses the elements lo to hi-1 of the line of x that starts at
+// start with the given stride.
+func reverse(x []complex128, start, stride, lo, hi int) {
+	for i, j := start+lo*stride, start+(hi-1)*stride; i < j; i, j = i+stride, j-stride {
+		x[i], x[j] = x[j], x[i]
+	}
+}
+
+// FFTFreq returns the frequencies of the n bins of a transform computed by FFT
+// or Plan.Execute, for samples spaced d apart: 0, 1, ..., then the negative
+// frequencies -n/2, ..., -1, all divided by d*n. FFTShift puts them in
+// increasing order.
+func FFTFreq(n int, d float64) []float64 {
+	if n <= 0 {
+		panic("fftw: n must be > 0")
+	}
+	f := make([]float64, n)
+	for i := range f {
+		k := i
+		if i >= (n+1)/2 {
+			k = i - n
+		}
+		f[i] = float64(k) / (d * float64(n))
+	}
+	return f
+}
+
+// RFFTFreq returns the frequencies of the n/2+1 bins of a real transform of
+// length n computed by RFFT or an R2C plan, for samples spaced d apart:
+// 0, 1, ..., n/2, all divided by d*n.
+func RFFTFreq(n int, d float64) []float64 {
+	if n <= 0 {
+		panic("fftw: n must be > 0")
+	}
+	f := make([]float64, n/2+1)
+	for i := range f {
+		f[i] = float64(i) / (d * float64(n))
+	}
+	return f
+}
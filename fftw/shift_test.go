@@ -0,0 +1,124 @@
+package fftw
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func rampArray(n int) *Array {
+	a := NewArray(n)
+	for i := range a.Elems {
+		a.Elems[i] = complex(float64(i), 0)
+	}
+	return a
+}
+
+func TestFFTShift(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		n             int
+		shift, ishift []float64
+	}{
+		{1, []float64{0}, []float64{0}},
+		{4, []float64{2, 3, 0, 1}, []float64{2, 3, 0, 1}},
+		{5, []float64{3, 4, 0, 1, 2}, []float64{2, 3, 4, 0, 1}},
+	} {
+		x := rampArray(tc.n)
+		y := FFTShift(x)
+		z := IFFTShift(x)
+		IFFTShiftTo(y, y)
+		for i := range x.Elems {
+			testAlmostEqual(t, real(FFTShift(x).Elems[i]), tc.shift[i])
+			testAlmostEqual(t, real(z.Elems[i]), tc.ishift[i])
+			testAlmostEqual(t, real(y.Elems[i]), real(x.Elems[i]))
+		}
+	}
+}
+
+func TestFFTShiftAxes(t *testing.T) {
+	t.Parallel()
+
+	x := NewArray2(3, 4)
+	for i := range x.Elems {
+		x.Elems[i] = complex(float64(i), 0)
+	}
+
+	// Rows move down by one, columns right by two.
+	y := FFTShift2(x)
+	testAlmostEqual(t, real(y.At(0, 0)), real(x.At(2, 2)))
+	testAlmostEqual(t, real(y.At(1, 3)), real(x.At(0, 1)))
+
+	y = FFTShift2(x, 1)
+	testAlmostEqual(t, real(y.At(0, 0)), real(x.At(0, 2)))
+	testAlmostEqual(t, real(y.At(2, 1)), real(x.At(2, 3)))
+
+	// In place along the last two axes only, and back.
+	n := NewArrayN([]int{2, 3, 5})
+	for i := range n.Elems {
+		n.Elems[i] = complex(float64(i), 0)
+	}
+	want := slices.Clone(n.Elems)
+	FFTShiftNTo(n, n, 2, 1)
+	testAlmostEqual(t, real(n.At([]int{1, 0, 0})), 28) // x[1][2][3]
+	IFFTShiftNTo(n, n, 1, 2)
+	if !slices.Equal(n.Elems, want) {
+		t.Fatalf("IFFTShiftNTo(FFTShiftNTo(x)) = %v, want %v", n.Elems, want)
+	}
+
+	a := NewArray3(2, 3, 4)
+	for i := range a.Elems {
+		a.Elems[i] = complex(float64(i), 0)
+	}
+	if b := IFFTShift3(FFTShift3(a, 0, 1), 0, 1); !slices.Equal(b.Elems, a.Elems) {
+		t.Fatalf("IFFTShift3(FFTShift3(x)) = %v, want %v", b.Elems, a.Elems)
+	}
+
+	expectPanic(t, "axis out of range", func() { FFTShift2(x, 2) })
+	expectPanic(t, "negative axis", func() { FFTShift3(a, -1) })
+	expectPanic(t, "duplicate axis", func() { FFTShift2(x, 1, 1) })
+	expectPanic(t, "dimensions mismatch", func() { FFTShift2To(NewArray2(4, 3), x) })
+}
+
+func TestFFTFreq(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		n           int
+		d           float64
+		freq, rfreq []float64
+	}{
+		{1, 1, []float64{0}, []float64{0}},
+		{4, 0.5, []float64{0, 0.5, -1, -0.5}, []float64{0, 0.5, 1}},
+		{5, 0.1, []float64{0, 2, 4, -4, -2}, []float64{0, 2, 4}},
+	} {
+		f, r := FFTFreq(tc.n, tc.d), RFFTFreq(tc.n, tc.d)
+		if len(f) != len(tc.freq) || len(r) != len(tc.rfreq) {
+			t.Fatalf("n=%d: got %d and %d frequencies, want %d and %d", tc.n, len(f), len(r), len(tc.freq), len(tc.rfreq))
+		}
+		for i := range f {
+			testAlmostEqual(t, f[i], tc.freq[i])
+		}
+		for i := range r {
+			testAlmostEqual(t, r[i], tc.rfreq[i])
+		}
+	}
+
+	// The peak of a cosine of 3 periods over 16 samples is at ±3 cycles per
+	// 16 samples.
+	x := NewArray(16)
+	for i := range x.Elems {
+		x.Elems[i] = complex(math.Cos(2*math.Pi*3*float64(i)/16), 0)
+	}
+	f := FFTFreq(16, 1)
+	y := FFT(x)
+	for k, v := range y.Elems {
+		if real(v) > 1 {
+			testAlmostEqual(t, f[k]*f[k], 3.0/16*3.0/16)
+		}
+	}
+
+	expectPanic(t, "zero length", func() { FFTFreq(0, 1) })
+	expectPanic(t, "zero length", func() { RFFTFreq(0, 1) })
+}
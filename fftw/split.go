@@ -0,0 +1,88 @@
+package fftw
+
+// SplitArray holds a 1D complex signal with its real and imaginary parts stored
+// in separate slices, sometimes called planar format.
+type SplitArray struct {
+	Re, Im []float64
+}
+
+func NewSplitArray(n int) *SplitArray {
+	return &SplitArray{make([]float64, n), make([]float64, n)}
+}
+
+func (a *SplitArray) Len() int {
+	return len(a.Re)
+}
+
+func (a *SplitArray) At(i int) complex128 {
+	return complex(a.Re[i], a.Im[i])
+}
+
+func (a *SplitArray) Set(i int, x complex128) {
+	a.Re[i], a.Im[i] = real(x), imag(x)
+}
+
+// 2D version of SplitArray.
+type SplitArray2 struct {
+	N      [2]int
+	Re, Im []float64
+}
+
+func NewSplitArray2(n0, n1 int) *SplitArray2 {
+	return &SplitArray2{[...]int{n0, n1}, make([]float64, n0*n1), make([]float64, n0*n1)}
+}
+
+func (a *SplitArray2) Dims() (int, int) {
+	return a.N[0], a.N[1]
+}
+
+func (a *SplitArray2) At(i0, i1 int) complex128 {
+	i := a.index(i0, i1)
+	return complex(a.Re[i], a.Im[i])
+}
+
+func (a *SplitArray2) Set(i0, i1 int, x complex128) {
+	i := a.index(i0, i1)
+	a.Re[i], a.Im[i] = real(x), imag(x)
+}
+
+func (a *SplitArray2) index(i0, i1 int) int {
+	return i1 + a.N[1]*i0
+}
+
+// N-dimensional version of SplitArray.
+type SplitArrayN struct {
+	N      []int
+	Re, Im []float64
+}
+
+func NewSplitArrayN(n []int) *SplitArrayN {
+	var a SplitArrayN
+	a.Re = make([]float64, prod(n))
+	a.Im = make([]float64, prod(n))
+	a.N = make([]int, len(n))
+	copy(a.N, n)
+	return &a
+}
+
+func (a *SplitArrayN) Dims() []int {
+	return a.N
+}
+
+func (a *SplitArrayN) At(i []int) complex128 {
+	m := a.index(i)
+	return complex(a.Re[m], a.Im[m])
+}
+
+func (a *SplitArrayN) Set(i []int, x complex128) {
+	m := a.index(i)
+	a.Re[m], a.Im[m] = real(x), imag(x)
+}
+
+func (a *SplitArrayN) index(i []int) int {
+	var m int
+	for d := range a.N {
+		m = m*a.N[d] + i[d]
+	}
+	return m
+}
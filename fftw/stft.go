@@ -0,0 +1,166 @@
+package fftw
+
+import (
+	"fmt"
+	"math"
+)
+
+// The relative tolerance of the overlap-add conditions, below the largest sum.
+const overlapTolerance = 1e-10
+
+// STFT computes the short-time Fourier transform of the real signal x with the
+// options opts: the DFTs of nfft points of the frames of x, hop samples apart,
+// weighted by window. It allocates memory in which to return the result, whose
+// row t holds the nfft/2+1 frequencies of frame t.
+//
+// Frame t is centered on sample t*hop of x, which is padded with zeros at both
+// ends, and holds len(window) <= nfft samples followed by nfft-len(window)
+// zeros. There are len(x)/hop+1 frames, so that they cover all of x.
+//
+// All the frames are transformed at once by a plan of NewPlanManyR2C, which is
+// created for the call, so WithCache has no effect.
+func STFT(x *RealArray, window []float64, hop, nfft int, opts ...Option) *Array2 {
+	frames := stftFrames(x.Len(), window, hop, nfft)
+	o := newOptions(opts)
+	bins := nfft/2 + 1
+	dst := NewArray2(frames, bins)
+	in := NewRealBatchArray([]int{nfft}, frames)
+	out := &BatchArray{N: []int{bins}, HowMany: frames, Stride: 1, Dist: bins, Embed: nil, Elems: dst.Elems}
+	plan := NewPlanManyR2C(in, out, o.flag)
+	defer plan.Destroy()
+
+	// Planning may have overwritten the frames.
+	clear(in.Elems)
+	half := len(window) / 2
+	for t := range frames {
+		frame := in.Elems[t*nfft : (t+1)*nfft]
+		for k, w := range window {
+			if i := t*hop + k - half; i >= 0 && i < x.Len() {
+				frame[k] = w * x.Elems[i]
+			}
+		}
+	}
+	plan.Execute()
+	scale(complexFloats(dst.Elems), o.norm.factor(nfft, Forward))
+	return dst
+}
+
+// ISTFT computes the inverse of STFT: the signal of n samples whose STFT with
+// the same window, hop, nfft and options opts is frames. It allocates memory in
+// which to return the result.
+//
+// The inverse DFTs of the frames are weighted by window again and added up,
+// then divided by the sum of the squared windows at each sample, which
+// reconstructs the signal to floating-point accuracy. If that sum is zero at
+// some sample, which cannot then be recovered, ISTFT panics; CheckNOLA tells
+// whether window and hop avoid it away from the ends of the signal.
+func ISTFT(frames *Array2, window []float64, hop, nfft, n int, opts ...Option) *RealArray {
+	count := stftFrames(n, window, hop, nfft)
+	bins := nfft/2 + 1
+	if n0, n1 := frames.Dims(); n0 != count || n1 != bins {
+		panic(fmt.Sprintf("fftw: frames are %dx%d, want %dx%d for %d samples", n0, n1, count, bins, n))
+	}
+	o := newOptions(opts)
+	// The frames are copied, as complex-to-real plans overwrite their input.
+	in := NewBatchArray([]int{bins}, count)
+	out := NewRealBatchArray([]int{nfft}, count)
+	plan := NewPlanManyC2R(in, out, o.flag)
+	defer plan.Destroy()
+	copy(in.Elems, frames.Elems)
+	plan.Execute()
+
+	dst := NewRealArray(n)
+	weight := make([]float64, n)
+	half := len(window) / 2
+	for t := range count {
+		frame := out.Elems[t*nfft : (t+1)*nfft]
+		for k, w := range window {
+			if i := t*hop + k - half; i >= 0 && i < n {
+				dst.Elems[i] += w * frame[k]
+				weight[i] += w * w
+			}
+		}
+	}
+	var peak float64
+	for _, w := range window {
+		peak = max(peak, w*w)
+	}
+	// The inverse DFTs are unscaled, and the frames scaled as opts require.
+	f := 1 / (float64(nfft) * o.norm.factor(nfft, Forward))
+	for i, w := range weight {
+		if w <= overlapTolerance*peak {
+			panic(fmt.Sprintf("fftw: window and hop %d fail the NOLA condition at sample %d", hop, i))
+		}
+		dst.Elems[i] *= f / w
+	}
+	return dst
+}
+
+// CheckCOLA reports whether window satisfies the constant overlap-add (COLA)
+// condition for hop: whether copies of it hop samples apart add up to a
+// constant, so that frames weighted by it can be added back without weights.
+func CheckCOLA(window []float64, hop int) bool {
+	sums := overlapSums(window, hop, false)
+	var peak float64
+	for _, s := range sums {
+		peak = max(peak, math.Abs(s))
+	}
+	for _, s := range sums {
+		if math.Abs(s-sums[0]) > overlapTolerance*peak {
+			return false
+		}
+	}
+	return peak > 0
+}
+
+// CheckNOLA reports whether window satisfies the nonzero overlap-add (NOLA)
+// condition for hop: whether the squares of copies of it hop samples apart add
+// up to more than zero everywhere, which ISTFT requires.
+func CheckNOLA(window []float64, hop int) bool {
+	sums := overlapSums(window, hop, true)
+	var peak float64
+	for _, s := range sums {
+		peak = max(peak, s)
+	}
+	for _, s := range sums {
+		if s <= overlapTolerance*peak {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapSums returns the sums of copies of window, or of its square, hop
+// samples apart, over a period of hop samples.
+func overlapSums(window []float64, hop int, square bool) []float64 {
+	if len(window) == 0 {
+		panic("fftw: window must be non-empty")
+	}
+	if hop <= 0 {
+		panic("fftw: hop must be > 0")
+	}
+	sums := make([]float64, hop)
+	for k, w := range window {
+		if square {
+			w *= w
+		}
+		sums[k%hop] += w
+	}
+	return sums
+}
+
+// stftFrames returns the number of frames of the STFT of n samples, and panics
+// if the arguments are invalid.
+func stftFrames(n int, window []float64, hop, nfft int) int {
+	switch {
+	case n <= 0:
+		panic("fftw: signal must be non-empty")
+	case len(window) == 0:
+		panic("fftw: window must be non-empty")
+	case hop <= 0:
+		panic("fftw: hop must be > 0")
+	case nfft < len(window):
+		panic("fftw: nfft must be at least the window length")
+	}
+	return n/hop + 1
+}
@@ -0,0 +1,124 @@
+package fftw
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/meko-christian/go-fftw/window"
+)
+
+func stftSignal(n int) *RealArray {
+	x := NewRealArray(n)
+	for i := range x.Elems {
+		x.Elems[i] = math.Sin(float64(i*i)) + 0.5
+	}
+	return x
+}
+
+func TestSTFT(t *testing.T) {
+	t.Parallel()
+
+	const n, hop, nfft = 100, 4, 20
+
+	x := stftSignal(n)
+	w := window.Hann(16, window.Periodic)
+	for _, norm := range []Norm{NormBackward, NormOrtho, NormForward} {
+		frames := STFT(x, w, hop, nfft, WithFlag(Measure), WithNorm(norm))
+		if n0, n1 := frames.Dims(); n0 != n/hop+1 || n1 != nfft/2+1 {
+			t.Fatalf("%v: frames are %dx%d", norm, n0, n1)
+		}
+
+		// Frame t is centered on sample t*hop.
+		for frame := range n/hop + 1 {
+			segment := NewRealArray(nfft)
+			for k := range w {
+				if i := frame*hop + k - len(w)/2; i >= 0 && i < n {
+					segment.Elems[k] = w[k] * x.Elems[i]
+				}
+			}
+			want := RFFTNorm(segment, norm)
+			for k := range nfft/2 + 1 {
+				if got := frames.At(frame, k); cmplx.Abs(got-want.Elems[k]) > 1e-10 {
+					t.Fatalf("%v: frame %d bin %d is %v, want %v", norm, frame, k, got, want.Elems[k])
+				}
+			}
+		}
+	}
+}
+
+func TestSTFTRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		w            window.Window
+		hop, nfft, n int
+		opts         []Option
+	}{
+		{"hann", window.Hann(16, window.Periodic), 4, 16, 100, nil},
+		{"hann half overlap", window.Hann(16, window.Periodic), 8, 16, 97, nil},
+		{"hann padded", window.Hann(15, window.Symmetric), 5, 32, 64, []Option{WithNorm(NormOrtho)}},
+		{"hamming", window.Hamming(32, window.Periodic), 7, 33, 300, []Option{WithFlag(Measure), WithThreads(2)}},
+		{"rectangular", window.Rectangular(8), 8, 8, 50, []Option{WithNorm(NormForward)}},
+		{"short signal", window.Blackman(64, window.Periodic), 16, 64, 5, nil},
+	} {
+		x := stftSignal(tc.n)
+		y := ISTFT(STFT(x, tc.w, tc.hop, tc.nfft, tc.opts...), tc.w, tc.hop, tc.nfft, tc.n, tc.opts...)
+		if y.Len() != tc.n {
+			t.Fatalf("%s: length %d, want %d", tc.name, y.Len(), tc.n)
+		}
+		for i := range x.Elems {
+			if math.Abs(y.Elems[i]-x.Elems[i]) > 1e-12 {
+				t.Fatalf("%s: sample %d is %v, want %v", tc.name, i, y.Elems[i], x.Elems[i])
+			}
+		}
+	}
+}
+
+func TestCheckCOLA(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		w          window.Window
+		hop        int
+		cola, nola bool
+	}{
+		{"periodic hann, half", window.Hann(16, window.Periodic), 8, true, true},
+		{"periodic hann, quarter", window.Hann(16, window.Periodic), 4, true, true},
+		{"periodic hann, no overlap", window.Hann(16, window.Periodic), 16, false, false},
+		{"symmetric hann", window.Hann(16, window.Symmetric), 8, false, true},
+		{"rectangular", window.Rectangular(12), 4, true, true},
+		{"rectangular, gaps", window.Rectangular(12), 13, false, false},
+		{"hamming", window.Hamming(32, window.Periodic), 16, true, true},
+		{"blackman, half", window.Blackman(32, window.Periodic), 16, false, true},
+		{"blackman, third", window.Blackman(33, window.Periodic), 11, true, true},
+	} {
+		if got := CheckCOLA(tc.w, tc.hop); got != tc.cola {
+			t.Errorf("%s: CheckCOLA = %v, want %v", tc.name, got, tc.cola)
+		}
+		if got := CheckNOLA(tc.w, tc.hop); got != tc.nola {
+			t.Errorf("%s: CheckNOLA = %v, want %v", tc.name, got, tc.nola)
+		}
+	}
+}
+
+func TestSTFTPanics(t *testing.T) {
+	t.Parallel()
+
+	x := stftSignal(32)
+	w := window.Hann(8, window.Periodic)
+	frames := STFT(x, w, 4, 8)
+
+	expectPanic(t, "empty window", func() { STFT(x, nil, 4, 8) })
+	expectPanic(t, "zero hop", func() { STFT(x, w, 0, 8) })
+	expectPanic(t, "short nfft", func() { STFT(x, w, 4, 7) })
+	expectPanic(t, "empty signal", func() { STFT(NewRealArray(0), w, 4, 8) })
+	expectPanic(t, "invalid norm", func() { STFT(x, w, 4, 8, WithNorm(Norm(7))) })
+	expectPanic(t, "frames mismatch", func() { ISTFT(frames, w, 4, 8, 40) })
+	expectPanic(t, "nfft mismatch", func() { ISTFT(frames, w, 4, 10, 32) })
+	expectPanic(t, "NOLA", func() { ISTFT(STFT(x, w, 8, 8), w, 8, 8, 32) })
+	expectPanic(t, "zero hop check", func() { CheckCOLA(w, 0) })
+	expectPanic(t, "empty window check", func() { CheckNOLA(nil, 4) })
+}
@@ -0,0 +1,30 @@
+package fftw
+
+import "fmt"
+
+const (
+	threadsShift = 24
+	threadsMask  = Flag(0xff) << threadsShift
+
+	// MaxThreads is the largest thread count that Threads can encode.
+	MaxThreads = 0xff
+)
+
+// Threads returns a flag asking for the plan to be created for n threads. It
+// can be combined with the other flags, as in Measure | Threads(4), and only
+// affects the plan it is passed to, so goroutines can use different thread
+// counts without racing on FFTW's global setting.
+//
+// Threads only has an effect when the package is built with the fftw_threads
+// build tag and InitThreads has been called; otherwise plans use one thread.
+func Threads(n int) Flag {
+	if n < 1 || n > MaxThreads {
+		panic(fmt.Sprintf("fftw: thread count must be between 1 and %d", MaxThreads))
+	}
+	return Flag(n) << threadsShift
+}
+
+// threads returns the thread count requested by f, or 0 if there is none.
+func (f Flag) threads() int {
+	return int((f & threadsMask) >> threadsShift)
+}
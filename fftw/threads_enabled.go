@@ -0,0 +1,78 @@
+//go:build fftw_threads && cgo && !purego
+
+package fftw
+
+// #cgo LDFLAGS: -lfftw3_threads -lfftw3 -lpthread
+// #include <fftw3.h>
+import "C"
+
+// Thread state of the planner, guarded by createDestroyMu.
+//
+//nolint:gochecknoglobals
+var (
+	threadsReady   bool
+	plannerThreads = 1
+)
+
+// InitThreads initializes FFTW's thread support. It must be called before
+// PlanWithNThreads or the Threads flag have any effect, ideally before any plan
+// is created.
+func InitThreads() error {
+	createDestroyMu.Lock()
+	defer createDestroyMu.Unlock()
+	if threadsReady {
+		return nil
+	}
+	if C.fftw_init_threads() == 0 {
+		return ErrThreads
+	}
+	threadsReady = true
+	return nil
+}
+
+// PlanWithNThreads sets the number of threads used by plans created without
+// the Threads flag. Existing plans are not affected.
+func PlanWithNThreads(n int) {
+	if n < 1 {
+		panic("fftw: thread count must be >= 1")
+	}
+	createDestroyMu.Lock()
+	defer createDestroyMu.Unlock()
+	if !threadsReady {
+		return
+	}
+	plannerThreads = n
+	C.fftw_plan_with_nthreads(C.int(n))
+}
+
+// CleanupThreads frees the resources of FFTW's thread support. All plans must
+// have been destroyed first, and InitThreads must be called again before
+// threads can be used.
+func CleanupThreads() {
+	createDestroyMu.Lock()
+	defer createDestroyMu.Unlock()
+	if !threadsReady {
+		return
+	}
+	C.fftw_cleanup_threads()
+	threadsReady = false
+	plannerThreads = 1
+}
+
+// lockPlanner acquires the planner lock and applies the thread count requested
+// by flag, if any.
+func lockPlanner(flag Flag) {
+	createDestroyMu.Lock()
+	if n := flag.threads(); n > 0 && threadsReady {
+		C.fftw_plan_with_nthreads(C.int(n))
+	}
+}
+
+// unlockPlanner restores the thread count changed by lockPlanner and releases
+// the planner lock.
+func unlockPlanner(flag Flag) {
+	if flag.threads() > 0 && threadsReady {
+		C.fftw_plan_with_nthreads(C.int(plannerThreads))
+	}
+	createDestroyMu.Unlock()
+}
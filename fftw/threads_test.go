@@ -0,0 +1,46 @@
+package fftw
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestThreadsFlag(t *testing.T) {
+	t.Parallel()
+
+	expectPanic(t, "zero threads", func() { Threads(0) })
+	expectPanic(t, "too many threads", func() { Threads(MaxThreads + 1) })
+
+	f := Measure | Threads(4)
+	if n := f.threads(); n != 4 {
+		t.Fatalf("expected 4 threads, got %d", n)
+	}
+
+	if f&^threadsMask != Measure {
+		t.Fatalf("thread count leaked into the FFTW flags: %#x", uint(f&^threadsMask))
+	}
+}
+
+func TestPlanWithThreads(t *testing.T) {
+	t.Parallel()
+
+	if err := InitThreads(); err != nil && !errors.Is(err, ErrThreads) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const n0, n1, n2 = 8, 6, 4
+
+	in := NewArray3(n0, n1, n2)
+	for i := range in.Elems {
+		in.Elems[i] = complex(float64(i%5), float64(i%3))
+	}
+
+	out := NewArray3(n0, n1, n2)
+	NewPlan3(in, out, Forward, Estimate|Threads(2)).Execute().Destroy()
+
+	want := FFT3(in)
+	for i := range want.Elems {
+		testAlmostEqual(t, real(out.Elems[i]), real(want.Elems[i]))
+		testAlmostEqual(t, imag(out.Elems[i]), imag(want.Elems[i]))
+	}
+}
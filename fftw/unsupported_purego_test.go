@@ -0,0 +1,92 @@
+//go:build !cgo || purego
+
+package fftw
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPureGoNoWisdom(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "wisdom")
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{"ExportWisdom", ExportWisdom(io.Discard)},
+		{"ImportWisdom", ImportWisdom(strings.NewReader("(fftw-3.3.10 fftw_wisdom)"))},
+		{"ImportWisdomString", ImportWisdomString("")},
+		{"ExportWisdomFile", ExportWisdomFile(name)},
+		{"ImportWisdomFile", ImportWisdomFile(name)},
+		{"ImportSystemWisdom", ImportSystemWisdom()},
+	} {
+		if !errors.Is(tc.err, ErrWisdom) {
+			t.Errorf("%s: expected ErrWisdom, got %v", tc.name, tc.err)
+		}
+	}
+	if s := ExportWisdomString(); s != "" {
+		t.Errorf("ExportWisdomString = %q, want empty", s)
+	}
+	if _, err := os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ExportWisdomFile created %s", name)
+	}
+	ForgetWisdom()
+}
+
+func TestPureGoNoGuruOrSplitPlans(t *testing.T) {
+	t.Parallel()
+
+	x, y := NewArray(4), NewArray(3)
+	rx := NewRealArray(4)
+	s, rs := NewSplitArray(4), NewSplitArray(3)
+	dims := []IODim{{N: 4, Is: 1, Os: 1}}
+	dims64 := []IODim64{{N: 4, Is: 1, Os: 1}}
+	for _, tc := range []struct {
+		name string
+		try  func() (*Plan, error)
+		must func()
+	}{
+		{
+			"Guru",
+			func() (*Plan, error) { return TryNewPlanGuru(dims, nil, x, x, Forward, Estimate) },
+			func() { NewPlanGuru(dims, nil, x, x, Forward, Estimate) },
+		},
+		{
+			"Guru64R2C",
+			func() (*Plan, error) { return TryNewPlanGuru64R2C(dims64, nil, rx, y, Estimate) },
+			func() { NewPlanGuru64R2C(dims64, nil, rx, y, Estimate) },
+		},
+		{
+			"GuruR2R",
+			func() (*Plan, error) { return TryNewPlanGuruR2R(dims, nil, rx, rx, []Kind{REDFT10}, Estimate) },
+			func() { NewPlanGuruR2R(dims, nil, rx, rx, []Kind{REDFT10}, Estimate) },
+		},
+		{
+			"Split",
+			func() (*Plan, error) { return TryNewPlanSplit(s, s, Forward, Estimate) },
+			func() { NewPlanSplit(s, s, Forward, Estimate) },
+		},
+		{
+			"SplitC2R",
+			func() (*Plan, error) { return TryNewPlanSplitC2R(rs, rx, Estimate) },
+			func() { NewPlanSplitC2R(rs, rx, Estimate) },
+		},
+	} {
+		if p, err := tc.try(); p != nil || !errors.Is(err, ErrNoPlan) {
+			t.Errorf("TryNewPlan%s: expected ErrNoPlan, got %v", tc.name, err)
+		}
+		expectPanic(t, "NewPlan"+tc.name, tc.must)
+	}
+
+	p := NewPlan(x, x, Forward, Estimate)
+	defer p.Destroy()
+	if err := p.ExecuteSplit(s.Re, s.Im, s.Re, s.Im); !errors.Is(err, ErrPlanKind) {
+		t.Errorf("ExecuteSplit: expected ErrPlanKind, got %v", err)
+	}
+}
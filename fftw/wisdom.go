@@ -0,0 +1,103 @@
+//go:build cgo && !purego
+
+package fftw
+
+// #include <stdlib.h>
+// #include <fftw3.h>
+import "C"
+
+import (
+	"io"
+	"os"
+	"unsafe"
+)
+
+// ExportWisdom writes the wisdom FFTW has accumulated, which records the plans
+// found by Measure and the more patient planner flags, to w.
+//
+// Double and single precision keep separate wisdom; this exports the double
+// precision wisdom used by this package.
+func ExportWisdom(w io.Writer) error {
+	_, err := io.WriteString(w, ExportWisdomString())
+	return err
+}
+
+// ImportWisdom reads wisdom previously written by ExportWisdom from r and adds
+// it to the wisdom of the planner, so that later plans are created quickly.
+func ImportWisdom(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return ImportWisdomString(string(b))
+}
+
+// ExportWisdomString returns the accumulated wisdom as a string.
+func ExportWisdomString() string {
+	createDestroyMu.Lock()
+	cstr := C.fftw_export_wisdom_to_string()
+	createDestroyMu.Unlock()
+	if cstr == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return C.GoString(cstr)
+}
+
+// ImportWisdomString adds the wisdom in s to the wisdom of the planner.
+func ImportWisdomString(s string) error {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+	createDestroyMu.Lock()
+	ok := C.fftw_import_wisdom_from_string(cstr)
+	createDestroyMu.Unlock()
+	if ok == 0 {
+		return ErrWisdom
+	}
+	return nil
+}
+
+// ExportWisdomFile writes the accumulated wisdom to the named file,
+// creating or truncating it.
+func ExportWisdomFile(name string) (err error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return ExportWisdom(f)
+}
+
+// ImportWisdomFile adds the wisdom stored in the named file to the wisdom of
+// the planner.
+func ImportWisdomFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ImportWisdom(f)
+}
+
+// ImportSystemWisdom adds the system-wide wisdom, usually /etc/fftw/wisdom,
+// to the wisdom of the planner.
+func ImportSystemWisdom() error {
+	createDestroyMu.Lock()
+	ok := C.fftw_import_system_wisdom()
+	createDestroyMu.Unlock()
+	if ok == 0 {
+		return ErrWisdom
+	}
+	return nil
+}
+
+// ForgetWisdom discards all accumulated wisdom. Existing plans are not affected.
+func ForgetWisdom() {
+	createDestroyMu.Lock()
+	C.fftw_forget_wisdom()
+	createDestroyMu.Unlock()
+}
@@ -0,0 +1,135 @@
+package fftw32
+
+import "fmt"
+
+// BatchArray holds HowMany transforms of dimensions N, in the layout of FFTW's
+// advanced interface.
+//
+// Element i of transform b is stored at Elems[b*Dist + Stride*j], where j is the
+// row-major index of i within an array of dimensions Embed. A nil Embed means the
+// transforms are not embedded in a larger array, i.e. Embed equals N.
+type BatchArray struct {
+	N       []int
+	HowMany int
+	Stride  int
+	Dist    int
+	Embed   []int
+	Elems   []complex64
+}
+
+// NewBatchArray allocates howmany contiguous transforms of dimensions n.
+func NewBatchArray(n []int, howmany int) *BatchArray {
+	a := &BatchArray{N: append([]int(nil), n...), HowMany: howmany, Stride: 1, Dist: prod(n)}
+	a.Elems = make([]complex64, a.Dist*howmany)
+	return a
+}
+
+func (a *BatchArray) At(b int, i []int) complex64 {
+	return a.Elems[a.index(b, i)]
+}
+
+func (a *BatchArray) Set(b int, i []int, x complex64) {
+	a.Elems[a.index(b, i)] = x
+}
+
+func (a *BatchArray) ptr() *complex64 {
+	return &a.Elems[0]
+}
+
+func (a *BatchArray) index(b int, i []int) int {
+	return batchIndex(a.N, a.Embed, a.Stride, a.Dist, b, i)
+}
+
+func (a *BatchArray) validate() {
+	validateBatch(a.N, a.Embed, a.HowMany, a.Stride, a.Dist, len(a.Elems))
+}
+
+// RealBatchArray is the real version of BatchArray.
+type RealBatchArray struct {
+	N       []int
+	HowMany int
+	Stride  int
+	Dist    int
+	Embed   []int
+	Elems   []float32
+}
+
+// NewRealBatchArray allocates howmany contiguous real transforms of dimensions n.
+func NewRealBatchArray(n []int, howmany int) *RealBatchArray {
+	a := &RealBatchArray{N: append([]int(nil), n...), HowMany: howmany, Stride: 1, Dist: prod(n)}
+	a.Elems = make([]float32, a.Dist*howmany)
+	return a
+}
+
+func (a *RealBatchArray) At(b int, i []int) float32 {
+	return a.Elems[a.index(b, i)]
+}
+
+func (a *RealBatchArray) Set(b int, i []int, x float32) {
+	a.Elems[a.index(b, i)] = x
+}
+
+func (a *RealBatchArray) ptr() *float32 {
+	return &a.Elems[0]
+}
+
+func (a *RealBatchArray) index(b int, i []int) int {
+	return batchIndex(a.N, a.Embed, a.Stride, a.Dist, b, i)
+}
+
+func (a *RealBatchArray) validate() {
+	validateBatch(a.N, a.Embed, a.HowMany, a.Stride, a.Dist, len(a.Elems))
+}
+
+func batchIndex(n, embed []int, stride, dist, b int, i []int) int {
+	if embed == nil {
+		embed = n
+	}
+	var m int
+	for d := range n {
+		m = m*embed[d] + i[d]
+	}
+	return b*dist + stride*m
+}
+
+// validateBatch panics unless the layout is one FFTW accepts and all of its
+// elements lie within the first length elements of the backing slice.
+func validateBatch(n, embed []int, howmany, stride, dist, length int) {
+	if len(n) == 0 || howmany <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	for _, ni := range n {
+		if ni <= 0 {
+			panic("fftw32: input and output must be non-empty")
+		}
+	}
+	if stride <= 0 || dist < 0 {
+		panic("fftw32: batch stride must be positive and distance non-negative")
+	}
+	if embed == nil {
+		embed = n
+	}
+	if len(embed) != len(n) {
+		panic("fftw32: batch embedding must have one dimension per transform dimension")
+	}
+	// FFTW ignores the first embedding dimension, as it only affects the
+	// distance between transforms.
+	for d := 1; d < len(n); d++ {
+		if embed[d] < n[d] {
+			panic("fftw32: batch embedding must be at least as large as the transform")
+		}
+	}
+	if ext := batchExtent(n, embed, howmany, stride, dist); ext > length {
+		panic(fmt.Sprintf("fftw32: batch spans %d elements, but only %d are allocated", ext, length))
+	}
+}
+
+// batchExtent returns the number of elements spanned by a batch.
+func batchExtent(n, embed []int, howmany, stride, dist int) int {
+	last, step := 0, stride
+	for d := len(n) - 1; d >= 0; d-- {
+		last += (n[d] - 1) * step
+		step *= embed[d]
+	}
+	return last + (howmany-1)*dist + 1
+}
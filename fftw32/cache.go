@@ -0,0 +1,416 @@
+package fftw32
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// DefaultCacheSize is the number of plans kept by the cache of the FFT helpers.
+const DefaultCacheSize = 64
+
+// The cache used by FFT, FFT2, FFT3, FFTN and their variants.
+//
+//nolint:gochecknoglobals
+var defaultCache = NewPlanCache(DefaultCacheSize)
+
+// DefaultPlanCache returns the cache used by the package-level FFT helpers, for
+// example to resize it or read its statistics.
+func DefaultPlanCache() *PlanCache {
+	return defaultCache
+}
+
+// PlanCache keeps the plans of recent transforms, so that transforming arrays of
+// the same shape again does not create a new plan. Plans are keyed by shape,
+// direction, flags, in-place-ness and alignment, and the least recently used
+// ones are destroyed when the cache is full. The plans are created on scratch
+// arrays, so the cache keeps no reference to the arrays it transforms.
+//
+// A PlanCache is safe for concurrent use: the cached plans are executed with
+// ExecuteOn, which FFTW allows on several arrays at once.
+type PlanCache struct {
+	mu      sync.Mutex
+	limit   int
+	lru     *list.List // Of *cacheEntry, most recently used first.
+	entries map[planKey]*list.Element
+	stats   CacheStats
+	closed  bool
+}
+
+// CacheStats are the statistics of a PlanCache.
+type CacheStats struct {
+	Hits, Misses, Evictions uint64
+	// The number of plans in the cache.
+	Len int
+}
+
+type planKey struct {
+	dims              string
+	kind              planKind
+	dir               Direction
+	flag              Flag
+	inPlace           bool
+	inAlign, outAlign int
+}
+
+type cacheEntry struct {
+	key  planKey
+	plan *Plan
+	// The number of transforms executing the plan, which is destroyed once it
+	// has been evicted and they are done.
+	users   int
+	evicted bool
+}
+
+// NewPlanCache returns a cache holding up to limit plans.
+func NewPlanCache(limit int) *PlanCache {
+	if limit < 1 {
+		panic("fftw32: plan cache limit must be at least 1")
+	}
+	return &PlanCache{limit: limit, lru: list.New(), entries: map[planKey]*list.Element{}}
+}
+
+// SetLimit changes the number of plans c holds, evicting plans if needed.
+func (c *PlanCache) SetLimit(limit int) {
+	if limit < 1 {
+		panic("fftw32: plan cache limit must be at least 1")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = limit
+	c.evict()
+}
+
+// Stats returns the hits, misses and evictions of c so far, and its size.
+func (c *PlanCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Len = c.lru.Len()
+	return s
+}
+
+// Close destroys the plans of c. Transforms started afterwards still work, but
+// create and destroy a plan every time.
+func (c *PlanCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+}
+
+// FFT computes the Fourier transform of src with a cached plan.
+// It allocates memory in which to return the result.
+func (c *PlanCache) FFT(src *Array) *Array {
+	dst := NewArray(src.Len())
+	c.FFTTo(dst, src)
+
+	return dst
+}
+
+// IFFT computes the inverse Fourier transform of src with a cached plan.
+// It allocates memory in which to return the result.
+func (c *PlanCache) IFFT(src *Array) *Array {
+	dst := NewArray(src.Len())
+	c.IFFTTo(dst, src)
+
+	return dst
+}
+
+// FFTTo computes the Fourier transform of src with a cached plan
+// and returns the result in dst.
+func (c *PlanCache) FFTTo(dst, src *Array) {
+	c.transform([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, Forward, DefaultFlag, unscaled)
+}
+
+// IFFTTo computes the inverse Fourier transform of src with a cached plan
+// and returns the result in dst.
+func (c *PlanCache) IFFTTo(dst, src *Array) {
+	c.transform([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, Backward, DefaultFlag, unscaled)
+}
+
+// 2D version of PlanCache.FFT.
+func (c *PlanCache) FFT2(src *Array2) *Array2 {
+	dst := NewArray2(src.Dims())
+	c.FFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of PlanCache.IFFT.
+func (c *PlanCache) IFFT2(src *Array2) *Array2 {
+	dst := NewArray2(src.Dims())
+	c.IFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of PlanCache.FFTTo.
+func (c *PlanCache) FFT2To(dst, src *Array2) {
+	c.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, Forward, DefaultFlag, unscaled)
+}
+
+// 2D version of PlanCache.IFFTTo.
+func (c *PlanCache) IFFT2To(dst, src *Array2) {
+	c.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, Backward, DefaultFlag, unscaled)
+}
+
+// 3D version of PlanCache.FFT.
+func (c *PlanCache) FFT3(src *Array3) *Array3 {
+	dst := NewArray3(src.Dims())
+	c.FFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of PlanCache.IFFT.
+func (c *PlanCache) IFFT3(src *Array3) *Array3 {
+	dst := NewArray3(src.Dims())
+	c.IFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of PlanCache.FFTTo.
+func (c *PlanCache) FFT3To(dst, src *Array3) {
+	c.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, Forward, DefaultFlag, unscaled)
+}
+
+// 3D version of PlanCache.IFFTTo.
+func (c *PlanCache) IFFT3To(dst, src *Array3) {
+	c.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, Backward, DefaultFlag, unscaled)
+}
+
+// N-dimensional version of PlanCache.FFT.
+func (c *PlanCache) FFTN(src *ArrayN) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	c.FFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of PlanCache.IFFT.
+func (c *PlanCache) IFFTN(src *ArrayN) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	c.IFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of PlanCache.FFTTo.
+func (c *PlanCache) FFTNTo(dst, src *ArrayN) {
+	c.transform(dst.N, src.N, dst.Elems, src.Elems, Forward, DefaultFlag, unscaled)
+}
+
+// N-dimensional version of PlanCache.IFFTTo.
+func (c *PlanCache) IFFTNTo(dst, src *ArrayN) {
+	c.transform(dst.N, src.N, dst.Elems, src.Elems, Backward, DefaultFlag, unscaled)
+}
+
+// transform computes the DFT of src, with dimensions srcDims, into dst and
+// scales it as norm requires.
+func (c *PlanCache) transform(dstDims, srcDims []int, dst, src []complex64, dir Direction, flag Flag, norm Norm) {
+	in := &ArrayN{srcDims, src}
+	out := &ArrayN{dstDims, dst}
+
+	e := c.acquire(planKey{
+		dims:     fmt.Sprint(srcDims),
+		kind:     dftPlan,
+		dir:      dir,
+		flag:     flag,
+		inPlace:  unsafe.SliceData(src) == unsafe.SliceData(dst),
+		inAlign:  AlignmentOf(src),
+		outAlign: AlignmentOf(dst),
+	}, srcDims, flag)
+	defer c.release(e)
+
+	if err := e.plan.ExecuteOnN(in, out); err != nil {
+		panic("fftw32: " + err.Error())
+	}
+	scale(complexFloats(dst), norm.factor(prod(srcDims), dir))
+}
+
+// transformR2C computes the DFT of the real array src with dimensions dims into
+// the half-spectrum dst, and scales it as norm requires.
+func (c *PlanCache) transformR2C(dims []int, dst []complex64, src []float32, flag Flag, norm Norm) {
+	in := &RealArrayN{N: dims, Elems: src, Padded: false}
+	out := &ArrayN{halfDims(dims), dst}
+
+	e := c.acquire(planKey{
+		dims:     fmt.Sprint(dims),
+		kind:     r2cPlan,
+		dir:      Forward,
+		flag:     flag,
+		inPlace:  false,
+		inAlign:  AlignmentOf(src),
+		outAlign: AlignmentOf(dst),
+	}, dims, flag)
+	defer c.release(e)
+
+	if err := e.plan.ExecuteR2COnN(in, out); err != nil {
+		panic("fftw32: " + err.Error())
+	}
+	scale(complexFloats(dst), norm.factor(prod(dims), Forward))
+}
+
+// transformC2R computes the inverse DFT of the half-spectrum src into the real
+// array dst with dimensions dims, and scales it as norm requires. Like the
+// plans of NewPlanC2R, it overwrites src.
+func (c *PlanCache) transformC2R(dims []int, dst []float32, src []complex64, flag Flag, norm Norm) {
+	in := &ArrayN{halfDims(dims), src}
+	out := &RealArrayN{N: dims, Elems: dst, Padded: false}
+
+	e := c.acquire(planKey{
+		dims:     fmt.Sprint(dims),
+		kind:     c2rPlan,
+		dir:      Backward,
+		flag:     flag,
+		inPlace:  false,
+		inAlign:  AlignmentOf(src),
+		outAlign: AlignmentOf(dst),
+	}, dims, flag)
+	defer c.release(e)
+
+	if err := e.plan.ExecuteC2ROnN(in, out); err != nil {
+		panic("fftw32: " + err.Error())
+	}
+	scale(dst, norm.factor(prod(dims), Backward))
+}
+
+// acquire returns the cache entry of the plan for key, of a transform with
+// dimensions dims, creating the plan if needed. The entry must be released
+// after use.
+//
+// Plans are created without holding c.mu, so that planning with Measure or
+// Patient does not block the other users of c.
+func (c *PlanCache) acquire(key planKey, dims []int, flag Flag) *cacheEntry {
+	c.mu.Lock()
+	if e := c.lookup(key); e != nil {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return e
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	plan := planFor(key, dims, flag)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another transform may have cached a plan for key meanwhile.
+	if e := c.lookup(key); e != nil {
+		plan.Destroy()
+		return e
+	}
+	e := &cacheEntry{key: key, plan: plan, users: 1, evicted: false}
+	if c.closed {
+		e.evicted = true
+		return e
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.evict()
+	return e
+}
+
+// lookup returns the cached entry for key, marked as used, or nil.
+// The caller must hold c.mu.
+func (c *PlanCache) lookup(key planKey) *cacheEntry {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	e := elem.Value.(*cacheEntry)
+	e.users++
+	return e
+}
+
+// planFor creates the plan for key, of a transform with dimensions dims. It
+// plans on scratch arrays with the alignment and in-place-ness of key, which
+// the plan drops afterwards, so that the planner flags other than Estimate do
+// not overwrite the arrays of the transform, and the cache keeps none of them.
+func planFor(key planKey, dims []int, flag Flag) *Plan {
+	// The number of floats of the input and output arrays.
+	inLen, outLen := 2*prod(dims), 2*prod(dims)
+	switch key.kind {
+	case r2cPlan:
+		inLen, outLen = prod(dims), 2*prod(halfDims(dims))
+	case c2rPlan:
+		inLen, outLen = 2*prod(halfDims(dims)), prod(dims)
+	}
+	in, inOK := scratch(inLen, key.inAlign)
+	out, outOK := in, inOK
+	if !key.inPlace {
+		out, outOK = scratch(outLen, key.outAlign)
+	}
+	if !inOK || !outOK {
+		// Only arrays made with unsafe can be aligned in no other way.
+		in, out = make([]float32, inLen), make([]float32, outLen)
+		if key.inPlace {
+			out = in
+		}
+		flag |= Unaligned
+	}
+
+	var p *Plan
+	switch key.kind {
+	case r2cPlan:
+		p = NewPlanR2CN(&RealArrayN{N: dims, Elems: in, Padded: false}, &ArrayN{halfDims(dims), floatsComplex(out)}, flag)
+	case c2rPlan:
+		p = NewPlanC2RN(&ArrayN{halfDims(dims), floatsComplex(in)}, &RealArrayN{N: dims, Elems: out, Padded: false}, flag)
+	default:
+		p = NewPlanN(&ArrayN{dims, floatsComplex(in)}, &ArrayN{dims, floatsComplex(out)}, key.dir, flag)
+	}
+	p.detach()
+	return p
+}
+
+// scratch returns n zeroed floats with the given alignment, as reported by
+// AlignmentOf, or false if the Go heap cannot provide it.
+func scratch(n, align int) ([]float32, bool) {
+	// Offsets of up to 32 bytes cover the alignment of every SIMD extension.
+	x := make([]float32, n+8)
+	for offset := range 8 {
+		if alignmentOf(unsafe.Pointer(&x[offset])) == align {
+			return x[offset : offset+n], true
+		}
+	}
+	return nil, false
+}
+
+// floatsComplex returns the complex elements of x, seen as pairs of reals.
+func floatsComplex(x []float32) []complex64 {
+	return unsafe.Slice((*complex64)(unsafe.Pointer(unsafe.SliceData(x))), len(x)/2)
+}
+
+func (c *PlanCache) release(e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.users--
+	if e.evicted && e.users == 0 {
+		e.plan.Destroy()
+	}
+}
+
+// evict removes the least recently used plans beyond the limit of c.
+// The caller must hold c.mu.
+func (c *PlanCache) evict() {
+	for c.lru.Len() > c.limit {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove takes elem out of c, destroying its plan unless it is in use.
+// The caller must hold c.mu.
+func (c *PlanCache) remove(elem *list.Element) {
+	e := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, e.key)
+	e.evicted = true
+	if e.users == 0 {
+		e.plan.Destroy()
+	}
+}
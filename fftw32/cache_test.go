@@ -0,0 +1,147 @@
+package fftw32
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func cosArray(n int) *Array {
+	a := NewArray(n)
+	for i := range a.Elems {
+		a.Elems[i] = complex(float32(math.Cos(float64(i)/float64(n)*math.Pi*2)), 0)
+	}
+	return a
+}
+
+func TestPlanCacheHits(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(4)
+	defer c.Close()
+
+	a := cosArray(16)
+	peakVerifier(t, c.FFT(a).Elems)
+	peakVerifier(t, c.FFT(a).Elems)
+
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 1 || s.Len != 1 {
+		t.Fatalf("Stats = %+v, want 1 hit, 1 miss, 1 plan", s)
+	}
+
+	// The inverse, in-place and 2D transforms need plans of their own.
+	c.IFFT(a)
+	c.FFTTo(a, a)
+	c.FFT2(NewArray2(4, 4))
+
+	if s := c.Stats(); s.Misses != 4 || s.Len != 4 {
+		t.Fatalf("Stats = %+v, want 4 misses, 4 plans", s)
+	}
+}
+
+func TestPlanCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(2)
+	defer c.Close()
+
+	for _, n := range []int{8, 16, 8, 32, 16} {
+		c.FFT(NewArray(n))
+	}
+
+	// 8 is used again before 32 evicts 16, which then evicts 8.
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 4 || s.Evictions != 2 || s.Len != 2 {
+		t.Fatalf("Stats = %+v, want 1 hit, 4 misses, 2 evictions, 2 plans", s)
+	}
+
+	c.SetLimit(1)
+
+	if s := c.Stats(); s.Evictions != 3 || s.Len != 1 {
+		t.Fatalf("Stats = %+v, want 3 evictions, 1 plan", s)
+	}
+}
+
+func TestPlanCacheClose(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(4)
+	c.FFT(NewArray(8))
+	c.Close()
+
+	if s := c.Stats(); s.Len != 0 {
+		t.Fatalf("Len = %d after Close", s.Len)
+	}
+
+	// A closed cache still transforms, without keeping the plans.
+	peakVerifier(t, c.FFT(cosArray(16)).Elems)
+
+	if s := c.Stats(); s.Len != 0 || s.Misses != 2 {
+		t.Fatalf("Stats = %+v, want 2 misses, no plans", s)
+	}
+}
+
+func TestPlanCacheConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(2)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 16 << (i % 3)
+			a := cosArray(n)
+			b := c.IFFT(c.FFT(a))
+			for j, x := range b.Elems {
+				if !almostEqual(real(x)/float32(n), real(a.Elems[j])) {
+					t.Errorf("size %d: element %d is %v after the round trip, want %v", n, j, x, a.Elems[j])
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPlanCacheMeasureKeepsInput(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(1)
+	defer c.Close()
+
+	a := cosArray(16)
+	dst := NewArray(16)
+	c.transform([]int{16}, []int{16}, dst.Elems, a.Elems, Forward, Measure, unscaled)
+
+	peakVerifier(t, dst.Elems)
+	testAlmostEqual(t, real(a.Elems[0]), 1)
+}
+
+func TestPlanCacheDropsArrays(t *testing.T) {
+	t.Parallel()
+
+	c := NewPlanCache(1)
+	defer c.Close()
+
+	// The cached plan must not keep the transformed array alive.
+	elems := make([]complex64, 1<<12)
+	collected := make(chan struct{})
+	runtime.AddCleanup(&elems[0], func(ch chan struct{}) { close(ch) }, collected)
+	c.FFT(&Array{elems})
+	if s := c.Stats(); s.Len != 1 {
+		t.Fatalf("Stats = %+v, want 1 plan", s)
+	}
+
+	for range 50 {
+		runtime.GC()
+		select {
+		case <-collected:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Fatal("the cache keeps the transformed array alive")
+}
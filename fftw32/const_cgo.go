@@ -0,0 +1,38 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// #include <fftw3.h>
+import "C"
+
+// The constants are defined without cgo for the pure-Go backend. Indexing with
+// their difference to the values of fftw3.h only compiles if it is zero.
+var (
+	_ = [1]struct{}{}[Forward-Direction(C.FFTW_FORWARD)]
+	_ = [1]struct{}{}[Backward-Direction(C.FFTW_BACKWARD)]
+	_ = [1]struct{}{}[Estimate-Flag(C.FFTW_ESTIMATE)]
+	_ = [1]struct{}{}[Measure-Flag(C.FFTW_MEASURE)]
+	_ = [1]struct{}{}[Patient-Flag(C.FFTW_PATIENT)]
+	_ = [1]struct{}{}[Exhaustive-Flag(C.FFTW_EXHAUSTIVE)]
+	_ = [1]struct{}{}[WisdomOnly-Flag(C.FFTW_WISDOM_ONLY)]
+	_ = [1]struct{}{}[DestroyInput-Flag(C.FFTW_DESTROY_INPUT)]
+	_ = [1]struct{}{}[PreserveInput-Flag(C.FFTW_PRESERVE_INPUT)]
+	_ = [1]struct{}{}[Unaligned-Flag(C.FFTW_UNALIGNED)]
+	_ = [1]struct{}{}[ConserveMemory-Flag(C.FFTW_CONSERVE_MEMORY)]
+	_ = [1]struct{}{}[R2HC-Kind(C.FFTW_R2HC)]
+	_ = [1]struct{}{}[HC2R-Kind(C.FFTW_HC2R)]
+	_ = [1]struct{}{}[DHT-Kind(C.FFTW_DHT)]
+	_ = [1]struct{}{}[REDFT00-Kind(C.FFTW_REDFT00)]
+	_ = [1]struct{}{}[REDFT01-Kind(C.FFTW_REDFT01)]
+	_ = [1]struct{}{}[REDFT10-Kind(C.FFTW_REDFT10)]
+	_ = [1]struct{}{}[REDFT11-Kind(C.FFTW_REDFT11)]
+	_ = [1]struct{}{}[RODFT00-Kind(C.FFTW_RODFT00)]
+	_ = [1]struct{}{}[RODFT01-Kind(C.FFTW_RODFT01)]
+	_ = [1]struct{}{}[RODFT10-Kind(C.FFTW_RODFT10)]
+	_ = [1]struct{}{}[RODFT11-Kind(C.FFTW_RODFT11)]
+)
+
+// cFlag returns the FFTW planner flags of flag, without the thread count.
+func cFlag(flag Flag) C.uint {
+	return C.uint(flag &^ threadsMask)
+}
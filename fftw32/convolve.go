@@ -0,0 +1,302 @@
+package fftw32
+
+import "fmt"
+
+// Mode selects the part of a convolution or correlation that is returned.
+type Mode int
+
+const (
+	// ModeFull returns the whole result, of n+m-1 elements along each axis.
+	ModeFull Mode = iota
+	// ModeSame returns the n elements centered in the full result, the size
+	// of the first input.
+	ModeSame
+	// ModeValid returns the |n-m|+1 elements computed without zero padding,
+	// where one input fully overlaps the other. One input must be at least as
+	// large as the other along every axis.
+	ModeValid
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeFull:
+		return "Full"
+	case ModeSame:
+		return "Same"
+	case ModeValid:
+		return "Valid"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Convolve computes the convolution of a and b through the DFT.
+// It allocates memory in which to return the result.
+//
+// The arrays are zero-padded to a size that FFTW transforms fast, see FastSize,
+// and the plans are kept in the cache of the FFT helpers, so convolving arrays
+// of the same shapes again does not create new plans.
+func Convolve(a, b *Array, mode Mode) *Array {
+	c := newConvolution([]int{a.Len()}, []int{b.Len()}, mode)
+	return &Array{Elems: c.ofComplex(a.Elems, b.Elems, false)}
+}
+
+// Correlate computes the cross-correlation of a and b, which is the
+// convolution of a with b reversed and conjugated.
+// It allocates memory in which to return the result.
+func Correlate(a, b *Array, mode Mode) *Array {
+	c := newConvolution([]int{a.Len()}, []int{b.Len()}, mode)
+	return &Array{Elems: c.ofComplex(a.Elems, b.Elems, true)}
+}
+
+// 2D version of Convolve.
+func Convolve2(a, b *Array2, mode Mode) *Array2 {
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &Array2{N: [2]int(c.out), Elems: c.ofComplex(a.Elems, b.Elems, false)}
+}
+
+// 2D version of Correlate.
+func Correlate2(a, b *Array2, mode Mode) *Array2 {
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &Array2{N: [2]int(c.out), Elems: c.ofComplex(a.Elems, b.Elems, true)}
+}
+
+// 3D version of Convolve.
+func Convolve3(a, b *Array3, mode Mode) *Array3 {
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &Array3{N: [3]int(c.out), Elems: c.ofComplex(a.Elems, b.Elems, false)}
+}
+
+// 3D version of Correlate.
+func Correlate3(a, b *Array3, mode Mode) *Array3 {
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &Array3{N: [3]int(c.out), Elems: c.ofComplex(a.Elems, b.Elems, true)}
+}
+
+// N-dimensional version of Convolve.
+func ConvolveN(a, b *ArrayN, mode Mode) *ArrayN {
+	c := newConvolution(a.N, b.N, mode)
+	return &ArrayN{N: c.out, Elems: c.ofComplex(a.Elems, b.Elems, false)}
+}
+
+// N-dimensional version of Correlate.
+func CorrelateN(a, b *ArrayN, mode Mode) *ArrayN {
+	c := newConvolution(a.N, b.N, mode)
+	return &ArrayN{N: c.out, Elems: c.ofComplex(a.Elems, b.Elems, true)}
+}
+
+// ConvolveReal is the version of Convolve for real arrays. It needs half the
+// transforms of Convolve, by transforming a and b together as the real and
+// imaginary parts of a complex array.
+func ConvolveReal(a, b *RealArray, mode Mode) *RealArray {
+	c := newConvolution([]int{a.Len()}, []int{b.Len()}, mode)
+	return &RealArray{Elems: c.ofReal(a.Elems, b.Elems, false)}
+}
+
+// CorrelateReal is the version of Correlate for real arrays.
+func CorrelateReal(a, b *RealArray, mode Mode) *RealArray {
+	c := newConvolution([]int{a.Len()}, []int{b.Len()}, mode)
+	return &RealArray{Elems: c.ofReal(a.Elems, b.Elems, true)}
+}
+
+// 2D version of ConvolveReal. The arrays must not be padded.
+func ConvolveReal2(a, b *RealArray2, mode Mode) *RealArray2 {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &RealArray2{N: [2]int(c.out), Elems: c.ofReal(a.Elems, b.Elems, false), Padded: false}
+}
+
+// 2D version of CorrelateReal. The arrays must not be padded.
+func CorrelateReal2(a, b *RealArray2, mode Mode) *RealArray2 {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &RealArray2{N: [2]int(c.out), Elems: c.ofReal(a.Elems, b.Elems, true), Padded: false}
+}
+
+// 3D version of ConvolveReal. The arrays must not be padded.
+func ConvolveReal3(a, b *RealArray3, mode Mode) *RealArray3 {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &RealArray3{N: [3]int(c.out), Elems: c.ofReal(a.Elems, b.Elems, false), Padded: false}
+}
+
+// 3D version of CorrelateReal. The arrays must not be padded.
+func CorrelateReal3(a, b *RealArray3, mode Mode) *RealArray3 {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N[:], b.N[:], mode)
+	return &RealArray3{N: [3]int(c.out), Elems: c.ofReal(a.Elems, b.Elems, true), Padded: false}
+}
+
+// N-dimensional version of ConvolveReal. The arrays must not be padded.
+func ConvolveRealN(a, b *RealArrayN, mode Mode) *RealArrayN {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N, b.N, mode)
+	return &RealArrayN{N: c.out, Elems: c.ofReal(a.Elems, b.Elems, false), Padded: false}
+}
+
+// N-dimensional version of CorrelateReal. The arrays must not be padded.
+func CorrelateRealN(a, b *RealArrayN, mode Mode) *RealArrayN {
+	checkUnpadded(a.Padded || b.Padded)
+	c := newConvolution(a.N, b.N, mode)
+	return &RealArrayN{N: c.out, Elems: c.ofReal(a.Elems, b.Elems, true), Padded: false}
+}
+
+func checkUnpadded(padded bool) {
+	if padded {
+		panic("fftw32: convolution does not support padded arrays")
+	}
+}
+
+// FastSize returns the smallest size of at least n whose only prime factors are
+// 2, 3, 5 and 7, which FFTW transforms fastest.
+func FastSize(n int) int {
+	for m := max(n, 1); ; m++ {
+		r := m
+		for _, p := range []int{2, 3, 5, 7} {
+			for r%p == 0 {
+				r /= p
+			}
+		}
+		if r == 1 {
+			return m
+		}
+	}
+}
+
+// convolution is the layout of the convolution of arrays with dimensions a and
+// b, computed through DFTs with dimensions padded.
+type convolution struct {
+	a, b, padded []int
+	// The dimensions of the result and its offset in the full convolution.
+	out, start []int
+}
+
+func newConvolution(a, b []int, mode Mode) convolution {
+	if len(a) == 0 || prod(a) == 0 || prod(b) == 0 {
+		panic("fftw32: input arrays must be non-empty")
+	}
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("fftw32: input dimensions %v and %v must have the same rank", a, b))
+	}
+	c := convolution{
+		a: a, b: b, padded: make([]int, len(a)),
+		out: make([]int, len(a)), start: make([]int, len(a)),
+	}
+	for i := range a {
+		c.padded[i] = FastSize(a[i] + b[i] - 1)
+	}
+	switch mode {
+	case ModeFull:
+		for i := range a {
+			c.out[i] = a[i] + b[i] - 1
+		}
+	case ModeSame:
+		for i := range a {
+			c.out[i] = a[i]
+			c.start[i] = (b[i] - 1) / 2
+		}
+	case ModeValid:
+		larger, smaller := a, b
+		if !dimsAtLeast(a, b) {
+			larger, smaller = b, a
+		}
+		if !dimsAtLeast(larger, smaller) {
+			panic(fmt.Sprintf("fftw32: %v mode needs one input at least as large as the other, got %v and %v", mode, a, b))
+		}
+		for i := range a {
+			c.out[i] = larger[i] - smaller[i] + 1
+			c.start[i] = smaller[i] - 1
+		}
+	default:
+		panic(fmt.Sprintf("fftw32: invalid %v", mode))
+	}
+	return c
+}
+
+// dimsAtLeast reports whether each of the dimensions a is at least that of b.
+func dimsAtLeast(a, b []int) bool {
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ofComplex returns the convolution of a and b, or their correlation.
+func (c convolution) ofComplex(a, b []complex64, correlate bool) []complex64 {
+	x := make([]complex64, prod(c.padded))
+	y := make([]complex64, len(x))
+	embed(c.a, c.padded, nil, false, func(i, j int) { x[j] = a[i] })
+	embed(c.b, c.padded, nil, correlate, func(i, j int) {
+		y[j] = b[i]
+		if correlate {
+			y[j] = complex(real(y[j]), -imag(y[j]))
+		}
+	})
+
+	defaultCache.transform(c.padded, c.padded, x, x, Forward, Estimate, unscaled)
+	defaultCache.transform(c.padded, c.padded, y, y, Forward, Estimate, unscaled)
+	for i := range x {
+		x[i] *= y[i]
+	}
+	defaultCache.transform(c.padded, c.padded, x, x, Backward, Estimate, NormBackward)
+
+	out := make([]complex64, prod(c.out))
+	embed(c.out, c.padded, c.start, false, func(i, j int) { out[i] = x[j] })
+	return out
+}
+
+// ofReal returns the convolution of the real arrays a and b, or their
+// correlation, through the product of their half-spectra.
+func (c convolution) ofReal(a, b []float32, correlate bool) []float32 {
+	x := make([]float32, prod(c.padded))
+	y := make([]float32, len(x))
+	embed(c.a, c.padded, nil, false, func(i, j int) { x[j] = a[i] })
+	embed(c.b, c.padded, nil, correlate, func(i, j int) { y[j] = b[i] })
+
+	half := prod(halfDims(c.padded))
+	xs, ys := make([]complex64, half), make([]complex64, half)
+	defaultCache.transformR2C(c.padded, xs, x, Estimate, unscaled)
+	defaultCache.transformR2C(c.padded, ys, y, Estimate, unscaled)
+	for i := range xs {
+		xs[i] *= ys[i]
+	}
+	defaultCache.transformC2R(c.padded, x, xs, Estimate, NormBackward)
+
+	out := make([]float32, prod(c.out))
+	embed(c.out, c.padded, c.start, false, func(i, j int) { out[i] = x[j] })
+	return out
+}
+
+// embed calls f(i, j) for each element i of a row-major array with dimensions
+// dims, with j its index in an array with dimensions outer that holds it at
+// offset, or at the origin if offset is nil, reversed along every axis if
+// reverse is set.
+func embed(dims, outer, offset []int, reverse bool, f func(i, j int)) {
+	idx := make([]int, len(dims))
+	strides := make([]int, len(dims))
+	stride := 1
+	for k := len(dims) - 1; k >= 0; k-- {
+		strides[k] = stride
+		stride *= outer[k]
+	}
+	for i := range prod(dims) {
+		j := 0
+		for k, x := range idx {
+			if reverse {
+				x = dims[k] - 1 - x
+			}
+			if offset != nil {
+				x += offset[k]
+			}
+			j += x * strides[k]
+		}
+		f(i, j)
+		for k := len(idx) - 1; k >= 0; k-- {
+			if idx[k]++; idx[k] < dims[k] {
+				break
+			}
+			idx[k] = 0
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import (
+	"fmt"
+	"io"
+)
+
+// Flops returns the number of floating-point additions and multiplications
+// performed by one execution of p. The pure-Go backend uses no fused
+// multiply-adds. A destroyed plan reports zero operations.
+func (p *Plan) Flops() (add, mul, fma float64) {
+	if p.destroyed() {
+		return 0, 0, 0
+	}
+	return p.adds, p.muls, 0
+}
+
+// Cost returns 0, because the pure-Go backend does not measure plans.
+func (p *Plan) Cost() float64 {
+	return 0
+}
+
+// EstimateCost returns the number of operations of p, as a heuristic estimate
+// of its cost.
+func (p *Plan) EstimateCost() float64 {
+	if p.destroyed() {
+		return 0
+	}
+	return p.adds + p.muls
+}
+
+// WriteTo writes the textual description of p to w. It implements io.WriterTo.
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	if p.destroyed() {
+		return 0, fmt.Errorf("%w: plan has been destroyed", ErrPlanKind)
+	}
+	n, err := io.WriteString(w, p.String())
+	return int64(n), err
+}
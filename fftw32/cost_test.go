@@ -0,0 +1,48 @@
+package fftw32
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlanCost(t *testing.T) {
+	t.Parallel()
+
+	p := NewPlan(NewArray(64), NewArray(64), Forward, Estimate)
+	defer p.Destroy()
+
+	add, mul, fma := p.Flops()
+	if add+mul+fma <= 0 {
+		t.Fatalf("expected a positive flop count, got %v %v %v", add, mul, fma)
+	}
+
+	if c := p.EstimateCost(); c <= 0 {
+		t.Fatalf("expected a positive estimated cost, got %v", c)
+	}
+
+	if c := p.Cost(); c < 0 {
+		t.Fatalf("expected a non-negative cost, got %v", c)
+	}
+
+	var sb strings.Builder
+
+	n, err := p.WriteTo(&sb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if int(n) != sb.Len() || sb.String() != p.String() {
+		t.Fatalf("WriteTo wrote %d bytes %q, String is %q", n, sb.String(), p.String())
+	}
+
+	p.Destroy()
+
+	if add, mul, fma := p.Flops(); add != 0 || mul != 0 || fma != 0 {
+		t.Fatalf("expected no flops for a destroyed plan, got %v %v %v", add, mul, fma)
+	}
+
+	if _, err := p.WriteTo(&sb); !errors.Is(err, ErrPlanKind) {
+		t.Fatalf("expected ErrPlanKind, got %v", err)
+	}
+}
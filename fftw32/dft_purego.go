@@ -0,0 +1,269 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// maxRadix is the largest prime factor handled by the mixed-radix algorithm;
+// lengths with larger prime factors use Bluestein's algorithm.
+const maxRadix = 13
+
+// dft1 computes one-dimensional DFTs of length n in one direction.
+type dft1 struct {
+	n    int
+	sign float64
+	// The radices of the mixed-radix algorithm, unless chirp is set.
+	radices []int
+	// twiddle[k] is exp(sign*2πik/n).
+	twiddle []complex128
+	// Bluestein's algorithm computes the DFT as a convolution with a chirp,
+	// through DFTs of a power-of-two length m.
+	chirp    []complex128 // exp(sign*πik²/n) for k < n.
+	filter   []complex128 // The DFT of the conjugate chirp, divided by m.
+	fwd, bwd *dft1
+	// The operations of one transform, for Flops.
+	adds, muls float64
+}
+
+func newDFT1(n int, dir Direction) *dft1 {
+	d := &dft1{
+		n: n, sign: float64(dir), radices: nil, twiddle: nil,
+		chirp: nil, filter: nil, fwd: nil, bwd: nil, adds: 0, muls: 0,
+	}
+	if radices, ok := factorize(n); ok {
+		d.radices = radices
+		d.twiddle = make([]complex128, n)
+		for k := range d.twiddle {
+			d.twiddle[k] = cis(d.sign * 2 * math.Pi * float64(k) / float64(n))
+		}
+		for _, p := range radices {
+			// Each stage multiplies every element by a twiddle factor and sums p terms.
+			d.muls += 4 * float64(n*p)
+			d.adds += 2*float64(n*p) + 2*float64(n*(p-1))
+		}
+		return d
+	}
+
+	m := 1
+	for m < 2*n-1 {
+		m *= 2
+	}
+	d.fwd = newDFT1(m, Forward)
+	d.bwd = newDFT1(m, Backward)
+	d.chirp = make([]complex128, n)
+	for k := range d.chirp {
+		// k² modulo 2n keeps the angle small, for accuracy.
+		d.chirp[k] = cis(d.sign * math.Pi * float64(k*k%(2*n)) / float64(n))
+	}
+	b := make([]complex128, m)
+	b[0] = conj(d.chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = conj(d.chirp[k])
+		b[m-k] = b[k]
+	}
+	d.filter = make([]complex128, m)
+	d.fwd.transform(d.filter, b, nil)
+	for i := range d.filter {
+		d.filter[i] /= complex(float64(m), 0)
+	}
+	d.muls = d.fwd.muls + d.bwd.muls + 4*float64(2*n+m)
+	d.adds = d.fwd.adds + d.bwd.adds + 2*float64(2*n+m)
+	return d
+}
+
+// factorize returns the radices of n, or false if n has a prime factor larger
+// than maxRadix.
+func factorize(n int) ([]int, bool) {
+	var radices []int
+	for n%4 == 0 {
+		radices = append(radices, 4)
+		n /= 4
+	}
+	for p := 2; p <= maxRadix && n > 1; p++ {
+		for n%p == 0 {
+			radices = append(radices, p)
+			n /= p
+		}
+	}
+	return radices, n == 1
+}
+
+// scratchLen returns the length of the scratch space transform needs.
+func (d *dft1) scratchLen() int {
+	if d.chirp == nil {
+		return 0
+	}
+	return 2 * len(d.filter)
+}
+
+// transform computes the DFT of src into dst, which must not overlap.
+func (d *dft1) transform(dst, src, scratch []complex128) {
+	if d.chirp == nil {
+		d.radix(dst[:d.n], src, 1, 1, d.radices)
+		return
+	}
+
+	m := len(d.filter)
+	a, fa := scratch[:m], scratch[m:2*m]
+	for k := range d.n {
+		a[k] = src[k] * d.chirp[k]
+	}
+	clear(a[d.n:])
+	d.fwd.transform(fa, a, nil)
+	for i := range fa {
+		fa[i] *= d.filter[i]
+	}
+	d.bwd.transform(a, fa, nil)
+	for k := range d.n {
+		dst[k] = a[k] * d.chirp[k]
+	}
+}
+
+// radix computes the DFT of the len(dst) elements src[0], src[stride], ... into
+// dst by decimation in time, where the roots of unity of that length are
+// twiddle[k*tstride].
+func (d *dft1) radix(dst, src []complex128, stride, tstride int, radices []int) {
+	if len(radices) == 0 {
+		dst[0] = src[0]
+		return
+	}
+	p := radices[0]
+	m := len(dst) / p
+	for r := range p {
+		d.radix(dst[r*m:(r+1)*m], src[r*stride:], stride*p, tstride*p, radices[1:])
+	}
+
+	switch p {
+	case 2:
+		for k := range m {
+			a, b := dst[k], dst[m+k]*d.twiddle[k*tstride]
+			dst[k], dst[m+k] = a+b, a-b
+		}
+	case 4:
+		for k := range m {
+			v0 := dst[k]
+			v1 := dst[m+k] * d.twiddle[k*tstride]
+			v2 := dst[2*m+k] * d.twiddle[2*k*tstride]
+			v3 := dst[3*m+k] * d.twiddle[3*k*tstride]
+			s02, d02 := v0+v2, v0-v2
+			s13, d13 := v1+v3, v1-v3
+			// The fourth root of unity is sign*i.
+			j13 := complex(-d.sign*imag(d13), d.sign*real(d13))
+			dst[k], dst[m+k], dst[2*m+k], dst[3*m+k] = s02+s13, d02+j13, s02-s13, d02-j13
+		}
+	default:
+		var v [maxRadix]complex128
+		for k := range m {
+			for r := range p {
+				v[r] = dst[r*m+k] * d.twiddle[r*k*tstride]
+			}
+			for q := range p {
+				s := v[0]
+				for r := 1; r < p; r++ {
+					s += v[r] * d.twiddle[(r*q%p)*m*tstride]
+				}
+				dst[q*m+k] = s
+			}
+		}
+	}
+}
+
+func (d *dft1) String() string {
+	if d.chirp != nil {
+		return fmt.Sprintf("(bluestein %d %v)", d.n, d.fwd)
+	}
+	radices := make([]string, len(d.radices))
+	for i, p := range d.radices {
+		radices[i] = fmt.Sprint(p)
+	}
+	return fmt.Sprintf("(mixed-radix %d %s)", d.n, strings.Join(radices, "*"))
+}
+
+// dftN computes the DFT of row-major arrays with dimensions dims, as
+// one-dimensional DFTs along each dimension.
+type dftN struct {
+	dims []int
+	axes []*dft1
+	// Scratch space for the lines of the transform.
+	pool sync.Pool
+}
+
+func newDFTN(dims []int, dir Direction) *dftN {
+	d := &dftN{dims: append([]int(nil), dims...), axes: make([]*dft1, len(dims)), pool: sync.Pool{}}
+	size := 0
+	for i, n := range dims {
+		// Dimensions of the same length share their transform.
+		for j := range i {
+			if dims[j] == n {
+				d.axes[i] = d.axes[j]
+			}
+		}
+		if d.axes[i] == nil {
+			d.axes[i] = newDFT1(n, dir)
+		}
+		size = max(size, 2*n+d.axes[i].scratchLen())
+	}
+	d.pool.New = func() any {
+		s := make([]complex128, size)
+		return &s
+	}
+	return d
+}
+
+// flops returns the operations of one transform.
+func (d *dftN) flops() (adds, muls float64) {
+	total := prod(d.dims)
+	for i, a := range d.axes {
+		lines := float64(total / d.dims[i])
+		adds += lines * a.adds
+		muls += lines * a.muls
+	}
+	return adds, muls
+}
+
+// transform computes the DFT of x in place.
+func (d *dftN) transform(x []complex128) {
+	buf := d.pool.Get().(*[]complex128)
+	defer d.pool.Put(buf)
+
+	total := prod(d.dims)
+	stride := 1
+	for axis := len(d.dims) - 1; axis >= 0; axis-- {
+		n := d.dims[axis]
+		line, out, scratch := (*buf)[:n], (*buf)[n:2*n], (*buf)[2*n:]
+		for base := 0; base < total; base += n * stride {
+			for i := base; i < base+stride; i++ {
+				for k := range n {
+					line[k] = x[i+k*stride]
+				}
+				d.axes[axis].transform(out, line, scratch)
+				for k := range n {
+					x[i+k*stride] = out[k]
+				}
+			}
+		}
+		stride *= n
+	}
+}
+
+func (d *dftN) String() string {
+	axes := make([]string, len(d.axes))
+	for i, a := range d.axes {
+		axes[i] = a.String()
+	}
+	return strings.Join(axes, " ")
+}
+
+func cis(theta float64) complex128 {
+	s, c := math.Sincos(theta)
+	return complex(c, s)
+}
+
+func conj(x complex128) complex128 {
+	return complex(real(x), -imag(x))
+}
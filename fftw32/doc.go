@@ -12,6 +12,18 @@ Provides simple functions to compute a transform without destroying existing dat
 
 Beware: Scaling is the same as in FFTW, so that computing forward and then inverse
 transforms scales the original input by the length of the sequence.
+The Norm variants of the functions scale the result with the semantics of numpy,
+so that the round trip reproduces the input:
+
+	xhat := fftw32.FFTNorm(x, fftw32.NormBackward)
+	x = fftw32.IFFTNorm(xhat, fftw32.NormBackward)
+
+Plan.ExecuteNormalized does the same for plans.
+
+The With variants of the functions take options for the planner flags, threads,
+normalization and plan cache of a single call:
+
+	xhat := fftw32.FFTWith(x, fftw32.WithFlag(fftw32.Measure), fftw32.WithNorm(fftw32.NormOrtho))
 
 Use a Plan explicitly to recycle memory and to do in-place transforms.
 Always remember to destroy a plan.
@@ -34,5 +46,11 @@ It's possible to use FFTW with memory not allocated by fftw.NewArrayX().
 	// or in-place
 	arr := &fftw.Array{x}
 	fftw.NewPlan(arr, arr, fftw.Forward, fftw.Estimate).Execute().Destroy()
+
+When built without cgo, or with the purego build tag, the package uses a pure-Go
+backend with mixed-radix and Bluestein algorithms instead of FFTW. It computes in
+double precision and provides the arrays, the basic, real, real-to-real and
+batched plans and the FFT helpers. The guru and split plans fail with ErrNoPlan,
+and the wisdom functions with ErrWisdom.
 */
 package fftw32
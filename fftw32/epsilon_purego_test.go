@@ -0,0 +1,8 @@
+//go:build !cgo || purego
+
+package fftw32
+
+// The absolute tolerance of almostEqual. The pure-Go backend rounds its results
+// to float32 once per transform, but not in the same way as FFTW, so a round
+// trip through the spectrum can be off by a few units in the last place.
+const almostEqualEpsilon = 0.00001
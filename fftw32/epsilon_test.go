@@ -0,0 +1,6 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// The absolute tolerance of almostEqual.
+const almostEqualEpsilon = 0.000001
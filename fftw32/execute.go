@@ -0,0 +1,224 @@
+package fftw32
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+type planKind int
+
+const (
+	dftPlan planKind = iota + 1
+	r2cPlan
+	c2rPlan
+	r2rPlan
+	splitDFTPlan
+	splitR2CPlan
+	splitC2RPlan
+)
+
+// layout records the arrays a plan was created for, so that it can be executed
+// on other arrays with the same layout. Plans without a layout, such as batched
+// plans, can only be executed on their own arrays.
+type layout struct {
+	kind planKind
+	// Set for backward split DFTs, which swap the real and imaginary parts.
+	backward bool
+	inPlace  bool
+	in, out  arrayShape
+	// Set for plans created with Unaligned, which accept arrays of any alignment.
+	unaligned bool
+	// fftw_alignment_of the planned input pointers followed by the output ones.
+	align [4]int
+}
+
+// arrayShape is the shape of one of the arrays of a plan.
+type arrayShape struct {
+	dims   []int
+	padded bool
+}
+
+func shape(dims []int, padded bool) arrayShape {
+	return arrayShape{append([]int(nil), dims...), padded}
+}
+
+// size returns the number of elements of an array with shape s, including padding.
+func (s arrayShape) size() int {
+	return prod(realDims(s.dims, s.padded))
+}
+
+func (s arrayShape) String() string {
+	if s.padded {
+		return fmt.Sprintf("%v (padded)", s.dims)
+	}
+	return fmt.Sprint(s.dims)
+}
+
+// buffer is an array passed to one of the ExecuteOn methods.
+type buffer struct {
+	// The shape of the array, or nil dims for the bare slices of split execution.
+	shape arrayShape
+	ptr   unsafe.Pointer
+	len   int
+}
+
+func newLayout(flag Flag, kind planKind, in, out arrayShape, inPtrs, outPtrs []unsafe.Pointer) layout {
+	l := layout{kind: kind, in: in, out: out, inPlace: inPtrs[0] == outPtrs[0], unaligned: flag&Unaligned != 0}
+	for i, p := range append(append([]unsafe.Pointer(nil), inPtrs...), outPtrs...) {
+		l.align[i] = alignmentOf(p)
+	}
+	return l
+}
+
+// check reports whether the buffers in and out may replace the planned arrays
+// of a plan of the given kind.
+func (p *Plan) check(kind planKind, in, out []buffer) error {
+	if p.destroyed() {
+		return fmt.Errorf("%w: plan has been destroyed", ErrPlanKind)
+	}
+	if p.layout.kind != kind {
+		return ErrPlanKind
+	}
+	for _, side := range []struct {
+		name string
+		bufs []buffer
+		want arrayShape
+	}{{"input", in, p.layout.in}, {"output", out, p.layout.out}} {
+		for _, b := range side.bufs {
+			if b.shape.dims != nil && (!equalDims(b.shape.dims, side.want.dims) || b.shape.padded != side.want.padded) {
+				return fmt.Errorf("%w: planned %s %v, got %v", ErrDimensionsMismatch, side.name, side.want, b.shape)
+			}
+			if b.len < side.want.size() {
+				return fmt.Errorf("%w: plan needs %d %s elements, got %d", ErrDimensionsMismatch, side.want.size(), side.name, b.len)
+			}
+		}
+	}
+	if (in[0].ptr == out[0].ptr) != p.layout.inPlace {
+		return ErrInPlace
+	}
+	if p.layout.unaligned {
+		return nil
+	}
+	for i, b := range append(append([]buffer(nil), in...), out...) {
+		if alignmentOf(b.ptr) != p.layout.align[i] {
+			return ErrMisaligned
+		}
+	}
+	return nil
+}
+
+// ExecuteOn executes the plan p, created by NewPlan or NewPlanGuru, on the arrays
+// in and out instead of the arrays it was created for.
+//
+// The arrays must have the dimensions of the planned arrays, be in-place exactly
+// when those were, and have the same alignment as reported by fftw_alignment_of,
+// unless the plan was created with Unaligned. Slices allocated by Go are usually,
+// but not always, aligned alike; an error is returned rather than executing the
+// plan on arrays that do not match.
+func (p *Plan) ExecuteOn(in, out *Array) error {
+	return p.executeDFT(complexBuffer(in.Elems, []int{in.Len()}), complexBuffer(out.Elems, []int{out.Len()}))
+}
+
+// 2D version of ExecuteOn, for plans created by NewPlan2.
+func (p *Plan) ExecuteOn2(in, out *Array2) error {
+	return p.executeDFT(complexBuffer(in.Elems, in.N[:]), complexBuffer(out.Elems, out.N[:]))
+}
+
+// 3D version of ExecuteOn, for plans created by NewPlan3.
+func (p *Plan) ExecuteOn3(in, out *Array3) error {
+	return p.executeDFT(complexBuffer(in.Elems, in.N[:]), complexBuffer(out.Elems, out.N[:]))
+}
+
+// N-dimensional version of ExecuteOn, for plans created by NewPlanN.
+func (p *Plan) ExecuteOnN(in, out *ArrayN) error {
+	return p.executeDFT(complexBuffer(in.Elems, in.N), complexBuffer(out.Elems, out.N))
+}
+
+// ExecuteR2COn is the version of ExecuteOn for plans created by NewPlanR2C or
+// NewPlanGuruR2C.
+func (p *Plan) ExecuteR2COn(in *RealArray, out *Array) error {
+	return p.executeR2C(realBuffer(in.Elems, []int{in.Len()}, false), complexBuffer(out.Elems, []int{out.Len()}))
+}
+
+// 2D version of ExecuteR2COn, for plans created by NewPlanR2C2.
+func (p *Plan) ExecuteR2COn2(in *RealArray2, out *Array2) error {
+	return p.executeR2C(realBuffer(in.Elems, in.N[:], in.Padded), complexBuffer(out.Elems, out.N[:]))
+}
+
+// 3D version of ExecuteR2COn, for plans created by NewPlanR2C3.
+func (p *Plan) ExecuteR2COn3(in *RealArray3, out *Array3) error {
+	return p.executeR2C(realBuffer(in.Elems, in.N[:], in.Padded), complexBuffer(out.Elems, out.N[:]))
+}
+
+// N-dimensional version of ExecuteR2COn, for plans created by NewPlanR2CN.
+func (p *Plan) ExecuteR2COnN(in *RealArrayN, out *ArrayN) error {
+	return p.executeR2C(realBuffer(in.Elems, in.N, in.Padded), complexBuffer(out.Elems, out.N))
+}
+
+// ExecuteC2ROn is the version of ExecuteOn for plans created by NewPlanC2R or
+// NewPlanGuruC2R. Like Execute, it overwrites the input.
+func (p *Plan) ExecuteC2ROn(in *Array, out *RealArray) error {
+	return p.executeC2R(complexBuffer(in.Elems, []int{in.Len()}), realBuffer(out.Elems, []int{out.Len()}, false))
+}
+
+// 2D version of ExecuteC2ROn, for plans created by NewPlanC2R2.
+func (p *Plan) ExecuteC2ROn2(in *Array2, out *RealArray2) error {
+	return p.executeC2R(complexBuffer(in.Elems, in.N[:]), realBuffer(out.Elems, out.N[:], out.Padded))
+}
+
+// 3D version of ExecuteC2ROn, for plans created by NewPlanC2R3.
+func (p *Plan) ExecuteC2ROn3(in *Array3, out *RealArray3) error {
+	return p.executeC2R(complexBuffer(in.Elems, in.N[:]), realBuffer(out.Elems, out.N[:], out.Padded))
+}
+
+// N-dimensional version of ExecuteC2ROn, for plans created by NewPlanC2RN.
+func (p *Plan) ExecuteC2ROnN(in *ArrayN, out *RealArrayN) error {
+	return p.executeC2R(complexBuffer(in.Elems, in.N), realBuffer(out.Elems, out.N, out.Padded))
+}
+
+// ExecuteR2ROn is the version of ExecuteOn for plans created by NewPlanR2R or
+// NewPlanGuruR2R.
+func (p *Plan) ExecuteR2ROn(in, out *RealArray) error {
+	return p.executeR2R(realBuffer(in.Elems, []int{in.Len()}, false), realBuffer(out.Elems, []int{out.Len()}, false))
+}
+
+// 2D version of ExecuteR2ROn, for plans created by NewPlanR2R2.
+func (p *Plan) ExecuteR2ROn2(in, out *RealArray2) error {
+	return p.executeR2R(realBuffer(in.Elems, in.N[:], in.Padded), realBuffer(out.Elems, out.N[:], out.Padded))
+}
+
+// 3D version of ExecuteR2ROn, for plans created by NewPlanR2R3.
+func (p *Plan) ExecuteR2ROn3(in, out *RealArray3) error {
+	return p.executeR2R(realBuffer(in.Elems, in.N[:], in.Padded), realBuffer(out.Elems, out.N[:], out.Padded))
+}
+
+// N-dimensional version of ExecuteR2ROn, for plans created by NewPlanR2RN.
+func (p *Plan) ExecuteR2ROnN(in, out *RealArrayN) error {
+	return p.executeR2R(realBuffer(in.Elems, in.N, in.Padded), realBuffer(out.Elems, out.N, out.Padded))
+}
+
+func complexBuffer(x []complex64, dims []int) buffer {
+	return buffer{arrayShape{dims, false}, unsafe.Pointer(unsafe.SliceData(x)), len(x)}
+}
+
+func realBuffer(x []float32, dims []int, padded bool) buffer {
+	return buffer{arrayShape{dims, padded}, unsafe.Pointer(unsafe.SliceData(x)), len(x)}
+}
+
+// planarBuffer describes one of the slices passed to the split execution methods.
+func planarBuffer(x []float32) buffer {
+	return buffer{arrayShape{nil, false}, unsafe.Pointer(unsafe.SliceData(x)), len(x)}
+}
+
+// realInPlace reports whether the real and complex sides of a transform share memory.
+//
+// FFTW expects in-place real transforms to use the padded layout, and the basic
+// interface assumes unpadded arrays otherwise, so an unpadded array used in place
+// is rejected.
+func realInPlace(padded bool, re, cplx unsafe.Pointer) bool {
+	inPlace := re == cplx
+	if inPlace && !padded {
+		panic("fftw32: in-place real transforms require a padded real array")
+	}
+	return inPlace
+}
@@ -0,0 +1,49 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// #include <fftw3.h>
+import "C"
+
+import "unsafe"
+
+// destroyed reports whether p has been destroyed.
+func (p *Plan) destroyed() bool {
+	return p.fftwP == nil
+}
+
+func (p *Plan) executeDFT(in, out buffer) error {
+	if err := p.check(dftPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	C.fftwf_execute_dft(p.fftwP, (*C.fftwf_complex)(in.ptr), (*C.fftwf_complex)(out.ptr))
+	return nil
+}
+
+func (p *Plan) executeR2C(in, out buffer) error {
+	if err := p.check(r2cPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	C.fftwf_execute_dft_r2c(p.fftwP, (*C.float)(in.ptr), (*C.fftwf_complex)(out.ptr))
+	return nil
+}
+
+func (p *Plan) executeC2R(in, out buffer) error {
+	if err := p.check(c2rPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	C.fftwf_execute_dft_c2r(p.fftwP, (*C.fftwf_complex)(in.ptr), (*C.float)(out.ptr))
+	return nil
+}
+
+func (p *Plan) executeR2R(in, out buffer) error {
+	if err := p.check(r2rPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	C.fftwf_execute_r2r(p.fftwP, (*C.float)(in.ptr), (*C.float)(out.ptr))
+	return nil
+}
+
+func alignmentOf(p unsafe.Pointer) int {
+	return int(C.fftwf_alignment_of((*C.float)(p)))
+}
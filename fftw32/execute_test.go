@@ -0,0 +1,173 @@
+package fftw32
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestExecuteOn(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	in, out := NewArray(n), NewArray(n)
+
+	p := NewPlan(in, out, Forward, Estimate)
+	defer p.Destroy()
+
+	src, dst := NewArray(n), NewArray(n)
+	for i := range src.Elems {
+		src.Elems[i] = complex(float32(math.Cos(float64(i)/n*math.Pi*2)), 0)
+	}
+
+	if err := p.ExecuteOn(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	peakVerifier(t, dst.Elems)
+
+	// The planned arrays are left alone.
+	for i := range out.Elems {
+		if out.Elems[i] != 0 {
+			t.Fatalf("ExecuteOn wrote to the planned output at %d", i)
+		}
+	}
+}
+
+func TestExecuteOnErrors(t *testing.T) {
+	t.Parallel()
+
+	p := NewPlan2(NewArray2(4, 6), NewArray2(4, 6), Forward, Estimate)
+	defer p.Destroy()
+
+	a, b := NewArray2(4, 6), NewArray2(4, 6)
+
+	if err := p.ExecuteOn2(a, b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := p.ExecuteOn2(a, NewArray2(6, 4)); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch, got %v", err)
+	}
+
+	short := &Array2{N: [2]int{4, 6}, Elems: a.Elems[:20]}
+	if err := p.ExecuteOn2(short, b); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch for short elements, got %v", err)
+	}
+
+	if err := p.ExecuteOn2(a, a); !errors.Is(err, ErrInPlace) {
+		t.Fatalf("expected ErrInPlace, got %v", err)
+	}
+
+	if err := p.ExecuteOn(NewArray(24), NewArray(24)); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch for a 1D array, got %v", err)
+	}
+
+	if err := p.ExecuteR2COn(NewRealArray(8), NewArray(5)); !errors.Is(err, ErrPlanKind) {
+		t.Fatalf("expected ErrPlanKind, got %v", err)
+	}
+
+	// Offsetting by one float32 changes the alignment that FFTW planned for.
+	shifted := &Array2{N: [2]int{4, 6}, Elems: complexView(make([]float32, 2*24+1)[1:], 24)}
+	if alignmentOf(unsafe.Pointer(&shifted.Elems[0])) != alignmentOf(unsafe.Pointer(&a.Elems[0])) {
+		if err := p.ExecuteOn2(shifted, b); !errors.Is(err, ErrMisaligned) {
+			t.Fatalf("expected ErrMisaligned, got %v", err)
+		}
+	}
+
+	p.Destroy()
+
+	if err := p.ExecuteOn2(a, b); !errors.Is(err, ErrPlanKind) {
+		t.Fatalf("expected ErrPlanKind for a destroyed plan, got %v", err)
+	}
+}
+
+func TestExecuteR2COnInPlace(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	a := NewRealArray2Padded(n0, n1)
+
+	p := NewPlanR2C2(a, a.Complex(), Estimate)
+	defer p.Destroy()
+
+	b := NewRealArray2Padded(n0, n1)
+	ref := NewRealArray2(n0, n1)
+
+	for i := range n0 {
+		for j := range n1 {
+			b.Set(i, j, float32(i-j*j))
+			ref.Set(i, j, float32(i-j*j))
+		}
+	}
+
+	if err := p.ExecuteR2COn2(b, NewArray2(n0, n1/2+1)); !errors.Is(err, ErrInPlace) {
+		t.Fatalf("expected ErrInPlace, got %v", err)
+	}
+
+	if err := p.ExecuteR2COn2(ref, NewArray2(n0, n1/2+1)); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch for an unpadded array, got %v", err)
+	}
+
+	if err := p.ExecuteR2COn2(b, b.Complex()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := RFFT2(ref)
+	got := b.Complex()
+
+	for i := range want.Elems {
+		testNearlyEqual(t, real(got.Elems[i]), real(want.Elems[i]))
+		testNearlyEqual(t, imag(got.Elems[i]), imag(want.Elems[i]))
+	}
+}
+
+func TestExecuteR2ROn(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+
+	p := NewPlanR2R(NewRealArray(n), NewRealArray(n), REDFT10, Estimate)
+	defer p.Destroy()
+
+	src, dst := NewRealArray(n), NewRealArray(n)
+	for i := range src.Elems {
+		src.Elems[i] = float32(i * i)
+	}
+
+	if err := p.ExecuteR2ROn(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := DCT(src)
+	for i := range want.Elems {
+		testNearlyEqual(t, dst.Elems[i], want.Elems[i])
+	}
+}
+
+func TestExecuteOnUnaligned(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+
+	p := NewPlan(NewArray(n), NewArray(n), Forward, Estimate|Unaligned)
+	defer p.Destroy()
+
+	// Misaligned by one float32, which a plan without Unaligned may reject.
+	in := &Array{complexView(make([]float32, 2*n+1)[1:], n)}
+	in.Elems[1] = 1
+
+	out := NewArray(n)
+	if err := p.ExecuteOn(in, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := FFT(&Array{append([]complex64(nil), in.Elems...)})
+	for i := range want.Elems {
+		testNearlyEqual(t, real(out.Elems[i]), real(want.Elems[i]))
+		testNearlyEqual(t, imag(out.Elems[i]), imag(want.Elems[i]))
+	}
+}
@@ -1,5 +1,12 @@
 package fftw32
 
+// DefaultFlag holds the planner flags of FFT, FFT2, FFT3, FFTN and their
+// variants.
+//
+// Deprecated: The helpers read DefaultFlag without synchronization, so changing
+// it while another goroutine transforms is a data race. Pass WithFlag to
+// FFTWith and its variants instead.
+//
 //nolint:gochecknoglobals
 var DefaultFlag = Estimate
 
@@ -7,7 +14,7 @@ var DefaultFlag = Estimate
 // Allocates memory in which to return the result.
 func FFT(src *Array) *Array {
 	dst := NewArray(src.Len())
-	fftTo(dst, src, Forward, DefaultFlag)
+	fftTo(dst, src, Forward, DefaultFlag, unscaled)
 
 	return dst
 }
@@ -16,13 +23,19 @@ func FFT(src *Array) *Array {
 // Allocates memory in which to return the result.
 func IFFT(src *Array) *Array {
 	dst := NewArray(src.Len())
-	fftTo(dst, src, Backward, DefaultFlag)
+	fftTo(dst, src, Backward, DefaultFlag, unscaled)
 
 	return dst
 }
 
-func fftTo(dst, src *Array, dir Direction, flag Flag) {
-	NewPlan(src, dst, dir, flag).Execute().Destroy()
+// Computes the DFT of src into dst, which must have the same length.
+func FFTTo(dst, src *Array) { fftTo(dst, src, Forward, DefaultFlag, unscaled) }
+
+// Computes the inverse DFT of src into dst, which must have the same length.
+func IFFTTo(dst, src *Array) { fftTo(dst, src, Backward, DefaultFlag, unscaled) }
+
+func fftTo(dst, src *Array, dir Direction, flag Flag, norm Norm) {
+	defaultCache.transform([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, dir, flag, norm)
 }
 
 // 2D version of FFT.
@@ -39,13 +52,19 @@ func IFFT2(src *Array2) *Array2 {
 func fft2(src *Array2, dir Direction) *Array2 {
 	n0, n1 := src.Dims()
 	dst := NewArray2(n0, n1)
-	fft2To(dst, src, dir, DefaultFlag)
+	fft2To(dst, src, dir, DefaultFlag, unscaled)
 
 	return dst
 }
 
-func fft2To(dst, src *Array2, dir Direction, flag Flag) {
-	NewPlan2(src, dst, dir, flag).Execute().Destroy()
+// 2D version of FFTTo.
+func FFT2To(dst, src *Array2) { fft2To(dst, src, Forward, DefaultFlag, unscaled) }
+
+// 2D version of IFFTTo.
+func IFFT2To(dst, src *Array2) { fft2To(dst, src, Backward, DefaultFlag, unscaled) }
+
+func fft2To(dst, src *Array2, dir Direction, flag Flag, norm Norm) {
+	defaultCache.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, dir, flag, norm)
 }
 
 // 3D version of FFT.
@@ -62,11 +81,248 @@ func IFFT3(src *Array3) *Array3 {
 func fft3(src *Array3, dir Direction) *Array3 {
 	n0, n1, n2 := src.Dims()
 	dst := NewArray3(n0, n1, n2)
-	fft3To(dst, src, dir, DefaultFlag)
+	fft3To(dst, src, dir, DefaultFlag, unscaled)
+
+	return dst
+}
+
+// 3D version of FFTTo.
+func FFT3To(dst, src *Array3) { fft3To(dst, src, Forward, DefaultFlag, unscaled) }
+
+// 3D version of IFFTTo.
+func IFFT3To(dst, src *Array3) { fft3To(dst, src, Backward, DefaultFlag, unscaled) }
+
+func fft3To(dst, src *Array3, dir Direction, flag Flag, norm Norm) {
+	defaultCache.transform(dst.N[:], src.N[:], dst.Elems, src.Elems, dir, flag, norm)
+}
+
+// N-dimensional version of FFT.
+func FFTN(src *ArrayN) *ArrayN {
+	return fftN(src, Forward)
+}
+
+// N-dimensional version of IFFT.
+func IFFTN(src *ArrayN) *ArrayN {
+	return fftN(src, Backward)
+}
+
+// Allocates memory.
+func fftN(src *ArrayN, dir Direction) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	fftNTo(dst, src, dir, DefaultFlag, unscaled)
+
+	return dst
+}
+
+// N-dimensional version of FFTTo.
+func FFTNTo(dst, src *ArrayN) { fftNTo(dst, src, Forward, DefaultFlag, unscaled) }
+
+// N-dimensional version of IFFTTo.
+func IFFTNTo(dst, src *ArrayN) { fftNTo(dst, src, Backward, DefaultFlag, unscaled) }
+
+func fftNTo(dst, src *ArrayN, dir Direction, flag Flag, norm Norm) {
+	defaultCache.transform(dst.N, src.N, dst.Elems, src.Elems, dir, flag, norm)
+}
+
+// Computes the DFT of a real signal.
+// Allocates memory in which to return the n/2+1 non-negative frequency terms.
+func RFFT(src *RealArray) *Array {
+	dst := NewArray(src.Len()/2 + 1)
+	RFFTTo(dst, src)
+
+	return dst
+}
+
+// Computes the inverse DFT of a Hermitian half-spectrum of n/2+1 elements.
+// Allocates memory in which to return the real signal of length n.
+func IRFFT(src *Array, n int) *RealArray {
+	dst := NewRealArray(n)
+	IRFFTTo(dst, src)
+
+	return dst
+}
+
+// Computes the DFT of a real signal into dst, which must hold n/2+1 elements.
+func RFFTTo(dst *Array, src *RealArray) { rfftTo(dst, src, unscaled) }
+
+func rfftTo(dst *Array, src *RealArray, norm Norm) {
+	NewPlanR2C(src, dst, DefaultFlag).ExecuteNormalized(norm).Destroy()
+}
+
+// Computes the inverse DFT of a Hermitian half-spectrum into the real signal dst.
+// Unlike executing a plan from NewPlanC2R, it leaves src intact.
+func IRFFTTo(dst *RealArray, src *Array) { irfftTo(dst, src, unscaled) }
+
+func irfftTo(dst *RealArray, src *Array, norm Norm) {
+	tmp := NewArray(src.Len())
+	p := NewPlanC2R(tmp, dst, DefaultFlag)
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm).Destroy()
+}
+
+// 2D version of RFFT.
+func RFFT2(src *RealArray2) *Array2 {
+	n0, n1 := src.Dims()
+	dst := NewArray2(n0, n1/2+1)
+	RFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of IRFFT, where n1 is the length of the real rows.
+func IRFFT2(src *Array2, n1 int) *RealArray2 {
+	n0, _ := src.Dims()
+	dst := NewRealArray2(n0, n1)
+	IRFFT2To(dst, src)
+
+	return dst
+}
+
+// 2D version of RFFTTo.
+func RFFT2To(dst *Array2, src *RealArray2) { rfft2To(dst, src, unscaled) }
+
+func rfft2To(dst *Array2, src *RealArray2, norm Norm) {
+	NewPlanR2C2(src, dst, DefaultFlag).ExecuteNormalized(norm).Destroy()
+}
+
+// 2D version of IRFFTTo.
+func IRFFT2To(dst *RealArray2, src *Array2) { irfft2To(dst, src, unscaled) }
+
+func irfft2To(dst *RealArray2, src *Array2, norm Norm) {
+	tmp := NewArray2(src.Dims())
+	p := NewPlanC2R2(tmp, dst, DefaultFlag)
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm).Destroy()
+}
+
+// 3D version of RFFT.
+func RFFT3(src *RealArray3) *Array3 {
+	n0, n1, n2 := src.Dims()
+	dst := NewArray3(n0, n1, n2/2+1)
+	RFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of IRFFT, where n2 is the length of the real rows.
+func IRFFT3(src *Array3, n2 int) *RealArray3 {
+	n0, n1, _ := src.Dims()
+	dst := NewRealArray3(n0, n1, n2)
+	IRFFT3To(dst, src)
+
+	return dst
+}
+
+// 3D version of RFFTTo.
+func RFFT3To(dst *Array3, src *RealArray3) { rfft3To(dst, src, unscaled) }
+
+func rfft3To(dst *Array3, src *RealArray3, norm Norm) {
+	NewPlanR2C3(src, dst, DefaultFlag).ExecuteNormalized(norm).Destroy()
+}
+
+// 3D version of IRFFTTo.
+func IRFFT3To(dst *RealArray3, src *Array3) { irfft3To(dst, src, unscaled) }
+
+func irfft3To(dst *RealArray3, src *Array3, norm Norm) {
+	tmp := NewArray3(src.Dims())
+	p := NewPlanC2R3(tmp, dst, DefaultFlag)
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm).Destroy()
+}
+
+// N-dimensional version of RFFT. The last dimension of the result is n/2+1.
+func RFFTN(src *RealArrayN) *ArrayN {
+	dst := NewArrayN(halfDims(src.Dims()))
+	RFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of IRFFT, where n is the length of the real rows.
+func IRFFTN(src *ArrayN, n int) *RealArrayN {
+	dims := append([]int(nil), src.Dims()...)
+	if len(dims) > 0 {
+		dims[len(dims)-1] = n
+	}
+	dst := NewRealArrayN(dims)
+	IRFFTNTo(dst, src)
+
+	return dst
+}
+
+// N-dimensional version of RFFTTo.
+func RFFTNTo(dst *ArrayN, src *RealArrayN) { rfftNTo(dst, src, unscaled) }
+
+func rfftNTo(dst *ArrayN, src *RealArrayN, norm Norm) {
+	NewPlanR2CN(src, dst, DefaultFlag).ExecuteNormalized(norm).Destroy()
+}
+
+// N-dimensional version of IRFFTTo.
+func IRFFTNTo(dst *RealArrayN, src *ArrayN) { irfftNTo(dst, src, unscaled) }
+
+func irfftNTo(dst *RealArrayN, src *ArrayN, norm Norm) {
+	tmp := NewArrayN(src.Dims())
+	p := NewPlanC2RN(tmp, dst, DefaultFlag)
+	copy(tmp.Elems, src.Elems)
+	p.ExecuteNormalized(norm).Destroy()
+}
+
+// Computes the type-II discrete cosine transform (REDFT10), without normalization.
+// Allocates memory in which to return the result.
+func DCT(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	DCTTo(dst, src)
+
+	return dst
+}
+
+// Computes the inverse of DCT, so that IDCT(DCT(x)) reproduces x.
+// Allocates memory in which to return the result.
+func IDCT(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	IDCTTo(dst, src)
+
+	return dst
+}
+
+// Computes the type-II discrete cosine transform into dst.
+func DCTTo(dst, src *RealArray) { r2rTo(dst, src, REDFT10, false) }
+
+// Computes the inverse of DCT into dst.
+func IDCTTo(dst, src *RealArray) { r2rTo(dst, src, REDFT01, true) }
+
+// Computes the type-II discrete sine transform (RODFT10), without normalization.
+// Allocates memory in which to return the result.
+func DST(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	DSTTo(dst, src)
 
 	return dst
 }
 
-func fft3To(dst, src *Array3, dir Direction, flag Flag) {
-	NewPlan3(src, dst, dir, flag).Execute().Destroy()
+// Computes the inverse of DST, so that IDST(DST(x)) reproduces x.
+// Allocates memory in which to return the result.
+func IDST(src *RealArray) *RealArray {
+	dst := NewRealArray(src.Len())
+	IDSTTo(dst, src)
+
+	return dst
+}
+
+// Computes the type-II discrete sine transform into dst.
+func DSTTo(dst, src *RealArray) { r2rTo(dst, src, RODFT10, false) }
+
+// Computes the inverse of DST into dst.
+func IDSTTo(dst, src *RealArray) { r2rTo(dst, src, RODFT01, true) }
+
+// If normalize is set, the result is divided by the logical size of the transform.
+func r2rTo(dst, src *RealArray, kind Kind, normalize bool) {
+	NewPlanR2R(src, dst, kind, DefaultFlag).Execute().Destroy()
+
+	if normalize {
+		scale := 1 / float32(kind.logicalSize(dst.Len()))
+		for i := range dst.Elems {
+			dst.Elems[i] *= scale
+		}
+	}
 }
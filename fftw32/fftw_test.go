@@ -180,8 +180,6 @@ func TestFFT3(t *testing.T) {
 	verifyFFT3(t, signal, dim0, dim1, dim2, freqX, freqY, freqZ)
 }
 
-const almostEqualEpsilon = 0.000001
-
 func almostEqual(v1, v2 float32) bool {
 	return math.Abs(float64(v1-v2)) < almostEqualEpsilon
 }
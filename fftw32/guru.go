@@ -0,0 +1,15 @@
+package fftw32
+
+// IODim describes one dimension of a guru plan: its length N and the distances
+// Is and Os, in elements, between successive input and output elements along it.
+//
+// IODim is used with the guru interface, which limits sizes and strides to 32 bits.
+type IODim struct {
+	N, Is, Os int
+}
+
+// IODim64 is the version of IODim for the guru64 interface, whose sizes and
+// strides are only limited by the size of int.
+type IODim64 struct {
+	N, Is, Os int
+}
@@ -1,3 +1,5 @@
+//go:build cgo && !purego
+
 package fftw32
 
 // #cgo CFLAGS: -I/usr/local/include
@@ -0,0 +1,92 @@
+package fftw32
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	x := NewArray2(n0, n1)
+	for i := range x.Elems {
+		x.Elems[i] = complex(float32(i%5), float32(i%3)-1)
+	}
+
+	for _, norm := range []Norm{NormBackward, NormOrtho, NormForward} {
+		y := IFFT2Norm(FFT2Norm(x, norm), norm)
+		for i := range x.Elems {
+			testAlmostEqual(t, real(y.Elems[i]), real(x.Elems[i]))
+			testAlmostEqual(t, imag(y.Elems[i]), imag(x.Elems[i]))
+		}
+	}
+}
+
+func TestNormScaling(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	// The peak of a cosine is n/2 without scaling.
+	for _, tc := range []struct {
+		norm Norm
+		peak float32
+	}{
+		{NormBackward, n / 2},
+		{NormOrtho, float32(n / 2 / math.Sqrt(n))},
+		{NormForward, 0.5},
+	} {
+		y := FFTNorm(cosArray(n), tc.norm)
+		testAlmostEqual(t, real(y.Elems[1]), tc.peak)
+		testAlmostEqual(t, real(y.Elems[n-1]), tc.peak)
+	}
+}
+
+func TestExecuteNormalized(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+
+	in := NewRealArray(n)
+	half := NewArray(n/2 + 1)
+	out := NewRealArray(n)
+
+	r2c := NewPlanR2C(in, half, Estimate)
+	defer r2c.Destroy()
+	c2r := NewPlanC2R(half, out, Estimate)
+	defer c2r.Destroy()
+
+	for i := range in.Elems {
+		in.Elems[i] = float32(i)
+	}
+
+	r2c.ExecuteNormalized(NormOrtho)
+	c2r.ExecuteNormalized(NormOrtho)
+
+	for i, x := range out.Elems {
+		testAlmostEqual(t, x, in.Elems[i])
+	}
+
+	r2c.ExecuteNormalized(NormForward)
+	testAlmostEqual(t, real(half.Elems[0]), 3.5) // The mean of 0, 1, ..., 7.
+
+	r2r := NewPlanR2R(in, out, REDFT10, Estimate)
+	defer r2r.Destroy()
+
+	expectPanic(t, "r2r plan", func() { r2r.ExecuteNormalized(NormBackward) })
+	expectPanic(t, "invalid norm", func() { r2c.ExecuteNormalized(Norm(3)) })
+}
+
+func TestNormString(t *testing.T) {
+	t.Parallel()
+
+	if s := NormOrtho.String(); s != "Ortho" {
+		t.Fatalf("NormOrtho.String() = %q", s)
+	}
+
+	if s := Norm(7).String(); s != "Norm(7)" {
+		t.Fatalf("Norm(7).String() = %q", s)
+	}
+}
@@ -0,0 +1,176 @@
+package fftw32
+
+// Option configures the transforms of FFTWith and its variants, and of STFT and ISTFT.
+type Option func(*options)
+
+type options struct {
+	flag    Flag
+	threads int
+	norm    Norm
+	cache   *PlanCache
+}
+
+// Plans created and destroyed for every transform, for WithCache(nil).
+//
+//nolint:gochecknoglobals
+var noCache = func() *PlanCache {
+	c := NewPlanCache(1)
+	c.Close()
+	return c
+}()
+
+// WithFlag sets the planner flags, Estimate by default. Plans are created on
+// scratch arrays, so flags such as Measure never overwrite the arrays passed in.
+func WithFlag(flag Flag) Option {
+	return func(o *options) { o.flag = flag }
+}
+
+// WithThreads sets the number of threads of the plan, replacing any Threads
+// flag passed to WithFlag. It panics if n is not between 1 and MaxThreads.
+func WithThreads(n int) Option {
+	Threads(n)
+	return func(o *options) { o.threads = n }
+}
+
+// WithNorm scales the result as norm requires. By default it is not scaled, as
+// with FFT and IFFT.
+func WithNorm(norm Norm) Option {
+	norm.factor(1, Forward)
+	return func(o *options) { o.norm = norm }
+}
+
+// WithCache sets the cache of the plans, DefaultPlanCache by default. With a
+// nil cache, a plan is created and destroyed for every transform.
+func WithCache(c *PlanCache) Option {
+	if c == nil {
+		c = noCache
+	}
+	return func(o *options) { o.cache = c }
+}
+
+// newOptions returns the defaults with opts applied, and the number of threads
+// folded into the planner flags.
+func newOptions(opts []Option) options {
+	o := options{flag: Estimate, threads: 0, norm: unscaled, cache: defaultCache}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.threads > 0 {
+		o.flag = o.flag&^threadsMask | Threads(o.threads)
+	}
+	return o
+}
+
+// transformWith computes the DFT of src into dst with the options opts.
+func transformWith(dstDims, srcDims []int, dst, src []complex64, dir Direction, opts []Option) {
+	o := newOptions(opts)
+	o.cache.transform(dstDims, srcDims, dst, src, dir, o.flag, o.norm)
+}
+
+// FFTWith computes the Fourier transform of src with the options opts.
+// It allocates memory in which to return the result.
+//
+//	y := fftw32.FFTWith(x, fftw32.WithFlag(fftw32.Measure), fftw32.WithNorm(fftw32.NormOrtho))
+func FFTWith(src *Array, opts ...Option) *Array {
+	dst := NewArray(src.Len())
+	FFTWithTo(dst, src, opts...)
+
+	return dst
+}
+
+// IFFTWith computes the inverse Fourier transform of src with the options opts.
+// It allocates memory in which to return the result.
+func IFFTWith(src *Array, opts ...Option) *Array {
+	dst := NewArray(src.Len())
+	IFFTWithTo(dst, src, opts...)
+
+	return dst
+}
+
+// FFTWithTo is the version of FFTWith that returns the result in dst.
+func FFTWithTo(dst, src *Array, opts ...Option) {
+	transformWith([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, Forward, opts)
+}
+
+// IFFTWithTo is the version of IFFTWith that returns the result in dst.
+func IFFTWithTo(dst, src *Array, opts ...Option) {
+	transformWith([]int{dst.Len()}, []int{src.Len()}, dst.Elems, src.Elems, Backward, opts)
+}
+
+// 2D version of FFTWith.
+func FFT2With(src *Array2, opts ...Option) *Array2 {
+	dst := NewArray2(src.Dims())
+	FFT2WithTo(dst, src, opts...)
+
+	return dst
+}
+
+// 2D version of IFFTWith.
+func IFFT2With(src *Array2, opts ...Option) *Array2 {
+	dst := NewArray2(src.Dims())
+	IFFT2WithTo(dst, src, opts...)
+
+	return dst
+}
+
+// 2D version of FFTWithTo.
+func FFT2WithTo(dst, src *Array2, opts ...Option) {
+	transformWith(dst.N[:], src.N[:], dst.Elems, src.Elems, Forward, opts)
+}
+
+// 2D version of IFFTWithTo.
+func IFFT2WithTo(dst, src *Array2, opts ...Option) {
+	transformWith(dst.N[:], src.N[:], dst.Elems, src.Elems, Backward, opts)
+}
+
+// 3D version of FFTWith.
+func FFT3With(src *Array3, opts ...Option) *Array3 {
+	dst := NewArray3(src.Dims())
+	FFT3WithTo(dst, src, opts...)
+
+	return dst
+}
+
+// 3D version of IFFTWith.
+func IFFT3With(src *Array3, opts ...Option) *Array3 {
+	dst := NewArray3(src.Dims())
+	IFFT3WithTo(dst, src, opts...)
+
+	return dst
+}
+
+// 3D version of FFTWithTo.
+func FFT3WithTo(dst, src *Array3, opts ...Option) {
+	transformWith(dst.N[:], src.N[:], dst.Elems, src.Elems, Forward, opts)
+}
+
+// 3D version of IFFTWithTo.
+func IFFT3WithTo(dst, src *Array3, opts ...Option) {
+	transformWith(dst.N[:], src.N[:], dst.Elems, src.Elems, Backward, opts)
+}
+
+// N-dimensional version of FFTWith.
+func FFTNWith(src *ArrayN, opts ...Option) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	FFTNWithTo(dst, src, opts...)
+
+	return dst
+}
+
+// N-dimensional version of IFFTWith.
+func IFFTNWith(src *ArrayN, opts ...Option) *ArrayN {
+	dst := NewArrayN(src.Dims())
+	IFFTNWithTo(dst, src, opts...)
+
+	return dst
+}
+
+// N-dimensional version of FFTWithTo.
+func FFTNWithTo(dst, src *ArrayN, opts ...Option) {
+	transformWith(dst.N, src.N, dst.Elems, src.Elems, Forward, opts)
+}
+
+// N-dimensional version of IFFTWithTo.
+func IFFTNWithTo(dst, src *ArrayN, opts ...Option) {
+	transformWith(dst.N, src.N, dst.Elems, src.Elems, Backward, opts)
+}
@@ -0,0 +1,166 @@
+//go:build cgo && !purego
+
+package fftw32
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewPlanGuruGuards(t *testing.T) {
+	t.Parallel()
+
+	var nilArray *Array
+
+	expectPanic(t, "nil input", func() {
+		NewPlanGuru([]IODim{{4, 1, 1}}, nil, nilArray, NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanGuru([]IODim{{4, 1, 1}}, nil, NewArray(0), NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "zero length", func() {
+		NewPlanGuru([]IODim{{0, 1, 1}}, nil, NewArray(4), NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "input stride out of bounds", func() {
+		NewPlanGuru([]IODim{{4, 2, 1}}, nil, NewArray(4), NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "output loop out of bounds", func() {
+		NewPlanGuru64([]IODim64{{4, 1, 1}}, []IODim64{{2, 4, 5}}, NewArray(8), NewArray(8), Forward, Estimate)
+	})
+
+	expectPanic(t, "negative stride", func() {
+		NewPlanGuru([]IODim{{4, -1, 1}}, nil, NewArray(4), NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "32-bit overflow", func() {
+		NewPlanGuru([]IODim{{1, math.MaxInt32 + 1, 1}}, nil, NewArray(4), NewArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "r2c rank", func() {
+		NewPlanGuruR2C(nil, nil, NewRealArray(4), NewArray(3), Estimate)
+	})
+
+	expectPanic(t, "r2c output out of bounds", func() {
+		NewPlanGuruR2C([]IODim{{8, 1, 1}}, nil, NewRealArray(8), NewArray(4), Estimate)
+	})
+
+	expectPanic(t, "r2r kinds", func() {
+		NewPlanGuruR2R([]IODim{{4, 1, 1}}, nil, NewRealArray(4), NewRealArray(4), nil, Estimate)
+	})
+
+	// There is no wisdom for this unusual size, so FFTW returns a NULL plan.
+	in, out := NewArray(1001), NewArray(1001)
+	if _, err := TryNewPlanGuru([]IODim{{1001, 1, 1}}, nil, in, out, Forward, Exhaustive|WisdomOnly); !errors.Is(err, ErrNoPlan) {
+		t.Errorf("expected ErrNoPlan, got %v", err)
+	}
+}
+
+func TestNewPlanGuruColumns(t *testing.T) {
+	t.Parallel()
+
+	const rows, cols = 8, 3
+
+	m := NewArray2(rows, cols)
+	for i := range m.Elems {
+		m.Elems[i] = complex(float32(i%5), float32(math.Cos(float64(i))))
+	}
+
+	want := make([]*Array, cols)
+	for j := range cols {
+		c := NewArray(rows)
+		for i := range rows {
+			c.Elems[i] = m.At(i, j)
+		}
+
+		want[j] = FFT(c)
+	}
+
+	// Transform the columns of m, writing them as the rows of a transposed matrix.
+	out := NewArray2(cols, rows)
+	dims := []IODim{{N: rows, Is: cols, Os: 1}}
+	howmany := []IODim{{N: cols, Is: 1, Os: rows}}
+
+	NewPlanGuru(dims, howmany, &Array{m.Elems}, &Array{out.Elems}, Forward, Estimate).Execute().Destroy()
+
+	for j := range cols {
+		for i := range rows {
+			testNearlyEqual(t, real(out.At(j, i)), real(want[j].Elems[i]))
+			testNearlyEqual(t, imag(out.At(j, i)), imag(want[j].Elems[i]))
+		}
+	}
+
+	// The same with the guru64 interface, in place.
+	dims64 := []IODim64{{N: rows, Is: cols, Os: cols}}
+	howmany64 := []IODim64{{N: cols, Is: 1, Os: 1}}
+	a := &Array{m.Elems}
+
+	NewPlanGuru64(dims64, howmany64, a, a, Forward, Estimate).Execute().Destroy()
+
+	for j := range cols {
+		for i := range rows {
+			testNearlyEqual(t, real(m.At(i, j)), real(want[j].Elems[i]))
+			testNearlyEqual(t, imag(m.At(i, j)), imag(want[j].Elems[i]))
+		}
+	}
+}
+
+func TestNewPlanGuruReal(t *testing.T) {
+	t.Parallel()
+
+	const rows, cols = 6, 4
+
+	m := NewRealArray2(rows, cols)
+	for i := range m.Elems {
+		m.Elems[i] = float32(i*i%11) - 5
+	}
+
+	col := func(j int) *RealArray {
+		c := NewRealArray(rows)
+		for i := range rows {
+			c.Elems[i] = m.At(i, j)
+		}
+
+		return c
+	}
+
+	// Half spectra of the columns, stored as columns of a (rows/2+1) x cols matrix.
+	spec := NewArray2(rows/2+1, cols)
+	dims := []IODim{{N: rows, Is: cols, Os: cols}}
+	howmany := []IODim{{N: cols, Is: 1, Os: 1}}
+
+	NewPlanGuruR2C(dims, howmany, &RealArray{m.Elems}, &Array{spec.Elems}, Estimate).Execute().Destroy()
+
+	for j := range cols {
+		want := RFFT(col(j))
+		for i := range rows/2 + 1 {
+			testNearlyEqual(t, real(spec.At(i, j)), real(want.Elems[i]))
+			testNearlyEqual(t, imag(spec.At(i, j)), imag(want.Elems[i]))
+		}
+	}
+
+	back := NewRealArray2(rows, cols)
+	dims64 := []IODim64{{N: rows, Is: cols, Os: cols}}
+	howmany64 := []IODim64{{N: cols, Is: 1, Os: 1}}
+
+	NewPlanGuru64C2R(dims64, howmany64, &Array{spec.Elems}, &RealArray{back.Elems}, Estimate).Execute().Destroy()
+
+	for i := range m.Elems {
+		testNearlyEqual(t, back.Elems[i], rows*m.Elems[i])
+	}
+
+	dct := NewRealArray2(rows, cols)
+	NewPlanGuru64R2R(dims64, howmany64, &RealArray{m.Elems}, &RealArray{dct.Elems}, []Kind{REDFT10}, Estimate).
+		Execute().Destroy()
+
+	for j := range cols {
+		want := DCT(col(j))
+		for i := range rows {
+			testNearlyEqual(t, dct.At(i, j), want.Elems[i])
+		}
+	}
+}
@@ -0,0 +1,165 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewPlanMany returns a plan for the in.HowMany transforms of the batch in,
+// written to the batch out.
+//
+// Planning a whole batch at once lets FFTW share work between the transforms,
+// which is much cheaper than creating one Plan per transform.
+func NewPlanMany(in, out *BatchArray, dir Direction, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, out.N) || in.HowMany != out.HowMany {
+		panic("fftw32: input and output dimensions must match")
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(in.N)
+	inEmbed, outEmbed := cInts(in.Embed), cInts(out.Embed)
+	var (
+		rank    = C.int(len(in.N))
+		howmany = C.int(in.HowMany)
+		inPtr   = (*C.fftwf_complex)(unsafe.Pointer(in.ptr()))
+		outPtr  = (*C.fftwf_complex)(unsafe.Pointer(out.ptr()))
+		dir_    = C.int(dir)
+		flag_   = cFlag(flag)
+	)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_many_dft(rank, &numElems[0], howmany,
+		inPtr, firstOrNil(inEmbed), C.int(in.Stride), C.int(in.Dist),
+		outPtr, firstOrNil(outEmbed), C.int(out.Stride), C.int(out.Dist),
+		dir_, flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanManyR2C returns a plan for the in.HowMany real-to-complex transforms of
+// the batch in, written to the batch out.
+//
+// The dimensions of out are those of in, except that the last one is n/2+1.
+func NewPlanManyR2C(in *RealBatchArray, out *BatchArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(out.N, halfDims(in.N)) || in.HowMany != out.HowMany {
+		panic("fftw32: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(in.N)
+	inEmbed, outEmbed := cInts(in.Embed), cInts(out.Embed)
+	var (
+		rank    = C.int(len(in.N))
+		howmany = C.int(in.HowMany)
+		inPtr   = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr  = (*C.fftwf_complex)(unsafe.Pointer(out.ptr()))
+		flag_   = cFlag(flag)
+	)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_many_dft_r2c(rank, &numElems[0], howmany,
+		inPtr, firstOrNil(inEmbed), C.int(in.Stride), C.int(in.Dist),
+		outPtr, firstOrNil(outEmbed), C.int(out.Stride), C.int(out.Dist),
+		flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanManyC2R returns a plan for the in.HowMany complex-to-real transforms of
+// the batch in, written to the batch out.
+//
+// The dimensions of in are those of out, except that the last one is n/2+1.
+// Beware that FFTW overwrites the input of complex-to-real transforms.
+func NewPlanManyC2R(in *BatchArray, out *RealBatchArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, halfDims(out.N)) || in.HowMany != out.HowMany {
+		panic("fftw32: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(out.N)
+	inEmbed, outEmbed := cInts(in.Embed), cInts(out.Embed)
+	var (
+		rank    = C.int(len(out.N))
+		howmany = C.int(in.HowMany)
+		inPtr   = (*C.fftwf_complex)(unsafe.Pointer(in.ptr()))
+		outPtr  = (*C.float)(unsafe.Pointer(out.ptr()))
+		flag_   = cFlag(flag)
+	)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_many_dft_c2r(rank, &numElems[0], howmany,
+		inPtr, firstOrNil(inEmbed), C.int(in.Stride), C.int(in.Dist),
+		outPtr, firstOrNil(outEmbed), C.int(out.Stride), C.int(out.Dist),
+		flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanManyR2R returns a plan for the in.HowMany real-to-real transforms of the
+// batch in, written to the batch out, applying kinds[i] along dimension i.
+func NewPlanManyR2R(in, out *RealBatchArray, kinds []Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, out.N) || in.HowMany != out.HowMany {
+		panic("fftw32: input and output dimensions must match")
+	}
+	if len(kinds) != len(in.N) {
+		panic("fftw32: need one kind per dimension")
+	}
+	kinds_ := make([]C.fftwf_r2r_kind, len(kinds))
+	for i, k := range kinds {
+		checkKind(k, in.N[i])
+		kinds_[i] = C.fftwf_r2r_kind(k)
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(in.N)
+	inEmbed, outEmbed := cInts(in.Embed), cInts(out.Embed)
+	var (
+		rank    = C.int(len(in.N))
+		howmany = C.int(in.HowMany)
+		inPtr   = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr  = (*C.float)(unsafe.Pointer(out.ptr()))
+		flag_   = cFlag(flag)
+	)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_many_r2r(rank, &numElems[0], howmany,
+		inPtr, firstOrNil(inEmbed), C.int(in.Stride), C.int(in.Dist),
+		outPtr, firstOrNil(outEmbed), C.int(out.Stride), C.int(out.Dist),
+		&kinds_[0], flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// firstOrNil returns a pointer to the first element of x, or nil if x is empty,
+// which FFTW's advanced interface takes to mean "not embedded".
+func firstOrNil(x []C.int) *C.int {
+	if len(x) == 0 {
+		return nil
+	}
+	return &x[0]
+}
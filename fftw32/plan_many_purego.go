@@ -0,0 +1,164 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// NewPlanMany returns a plan for the in.HowMany transforms of the batch in,
+// written to the batch out.
+//
+// The pure-Go backend computes the transforms one after the other, through a
+// contiguous copy of each.
+func NewPlanMany(in, out *BatchArray, dir Direction, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, out.N) || in.HowMany != out.HowMany {
+		panic("fftw32: input and output dimensions must match")
+	}
+	n := prod(in.N)
+	x, y := make([]complex64, n), make([]complex64, n)
+	inner, err := newDFTPlan(in.N, x, y, dir, flag)
+	inOff, outOff := batchOffsets(in.N, in.Embed, in.Stride), batchOffsets(out.N, out.Embed, out.Stride)
+	return mustPlan(newBatchPlan(inner, err, in.HowMany, func(b int) {
+		for i, o := range inOff {
+			x[i] = in.Elems[b*in.Dist+o]
+		}
+		inner.run(unsafe.Pointer(unsafe.SliceData(x)), unsafe.Pointer(unsafe.SliceData(y)))
+		for i, o := range outOff {
+			out.Elems[b*out.Dist+o] = y[i]
+		}
+	}))
+}
+
+// NewPlanManyR2C returns a plan for the in.HowMany real-to-complex transforms of
+// the batch in, written to the batch out.
+//
+// The dimensions of out are those of in, except that the last one is n/2+1.
+func NewPlanManyR2C(in *RealBatchArray, out *BatchArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(out.N, halfDims(in.N)) || in.HowMany != out.HowMany {
+		panic("fftw32: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	x, y := make([]float32, prod(in.N)), make([]complex64, prod(out.N))
+	inner, err := newR2CPlan(in.N, false, x, y, flag)
+	inOff, outOff := batchOffsets(in.N, in.Embed, in.Stride), batchOffsets(out.N, out.Embed, out.Stride)
+	return mustPlan(newBatchPlan(inner, err, in.HowMany, func(b int) {
+		for i, o := range inOff {
+			x[i] = in.Elems[b*in.Dist+o]
+		}
+		inner.run(unsafe.Pointer(unsafe.SliceData(x)), unsafe.Pointer(unsafe.SliceData(y)))
+		for i, o := range outOff {
+			out.Elems[b*out.Dist+o] = y[i]
+		}
+	}))
+}
+
+// NewPlanManyC2R returns a plan for the in.HowMany complex-to-real transforms of
+// the batch in, written to the batch out.
+//
+// The dimensions of in are those of out, except that the last one is n/2+1.
+// Beware that FFTW overwrites the input of complex-to-real transforms.
+func NewPlanManyC2R(in *BatchArray, out *RealBatchArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, halfDims(out.N)) || in.HowMany != out.HowMany {
+		panic("fftw32: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	x, y := make([]complex64, prod(in.N)), make([]float32, prod(out.N))
+	inner, err := newC2RPlan(out.N, false, x, y, flag)
+	inOff, outOff := batchOffsets(in.N, in.Embed, in.Stride), batchOffsets(out.N, out.Embed, out.Stride)
+	return mustPlan(newBatchPlan(inner, err, in.HowMany, func(b int) {
+		for i, o := range inOff {
+			x[i] = in.Elems[b*in.Dist+o]
+		}
+		inner.run(unsafe.Pointer(unsafe.SliceData(x)), unsafe.Pointer(unsafe.SliceData(y)))
+		for i, o := range outOff {
+			out.Elems[b*out.Dist+o] = y[i]
+		}
+	}))
+}
+
+// NewPlanManyR2R returns a plan for the in.HowMany real-to-real transforms of the
+// batch in, written to the batch out, applying kinds[i] along dimension i.
+func NewPlanManyR2R(in, out *RealBatchArray, kinds []Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in.validate()
+	out.validate()
+	if !equalDims(in.N, out.N) || in.HowMany != out.HowMany {
+		panic("fftw32: input and output dimensions must match")
+	}
+	if len(kinds) != len(in.N) {
+		panic("fftw32: need one kind per dimension")
+	}
+	for i, k := range kinds {
+		checkKind(k, in.N[i])
+	}
+	n := prod(in.N)
+	x, y := make([]float32, n), make([]float32, n)
+	inner, err := newR2RPlan(in.N, kinds, x, y, flag)
+	inOff, outOff := batchOffsets(in.N, in.Embed, in.Stride), batchOffsets(out.N, out.Embed, out.Stride)
+	return mustPlan(newBatchPlan(inner, err, in.HowMany, func(b int) {
+		for i, o := range inOff {
+			x[i] = in.Elems[b*in.Dist+o]
+		}
+		inner.run(unsafe.Pointer(unsafe.SliceData(x)), unsafe.Pointer(unsafe.SliceData(y)))
+		for i, o := range outOff {
+			out.Elems[b*out.Dist+o] = y[i]
+		}
+	}))
+}
+
+// newBatchPlan returns a plan that calls transform for each of the howmany
+// transforms of a batch, which inner computes. Like FFTW's batched plans, it
+// cannot be executed on other arrays.
+func newBatchPlan(inner *Plan, err error, howmany int, transform func(b int)) (*Plan, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{
+		layout:  layout{},
+		scaling: scaling{},
+		in:      nil,
+		out:     nil,
+		run: func(unsafe.Pointer, unsafe.Pointer) {
+			for b := range howmany {
+				transform(b)
+			}
+		},
+		desc: fmt.Sprintf("(batch %d %s)", howmany, inner.desc),
+		adds: float64(howmany) * inner.adds,
+		muls: float64(howmany) * inner.muls,
+	}, nil
+}
+
+// batchOffsets returns the offset of each element, in row-major order, of a
+// transform of dimensions n in a batch with the given embedding and stride.
+func batchOffsets(n, embed []int, stride int) []int {
+	offsets := make([]int, prod(n))
+	i := make([]int, len(n))
+	for m := range offsets {
+		offsets[m] = batchIndex(n, embed, stride, 0, 0, i)
+		for d := len(i) - 1; d >= 0; d-- {
+			if i[d]++; i[d] < n[d] {
+				break
+			}
+			i[d] = 0
+		}
+	}
+	return offsets
+}
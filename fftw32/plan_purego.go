@@ -0,0 +1,281 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Plans of the pure-Go backend need no locking, but the threads stubs share
+// the planner lock of the cgo backend.
+//
+//nolint:gochecknoglobals
+var createDestroyMu sync.Mutex
+
+// NoTimeLimit removes the planning time limit when passed to SetTimeLimit.
+const NoTimeLimit time.Duration = -1
+
+// SetTimeLimit does nothing in the pure-Go backend, which does not measure
+// plans.
+func SetTimeLimit(time.Duration) {}
+
+// Plan is a transform computed by the pure-Go backend, which is used when the
+// package is built without cgo or with the purego build tag. It computes in
+// double precision and rounds the results to single precision.
+type Plan struct {
+	// The arrays the plan was created for, to check those passed to ExecuteOn.
+	layout layout
+	// The size and output of the transform, for ExecuteNormalized.
+	scaling scaling
+	// The planned arrays.
+	in, out unsafe.Pointer
+	// run computes the transform of the arrays at in and out. It is nil once
+	// the plan has been destroyed.
+	run  func(in, out unsafe.Pointer)
+	desc string
+	// The operations of one execution, for Flops.
+	adds, muls float64
+}
+
+// NewPlanForSize allocates input/output arrays of length n and returns a plan for them.
+//
+// This is a convenience helper for callers who want to create and reuse a plan based
+// solely on the transform size.
+func NewPlanForSize(n int, dir Direction, flag Flag) (p *Plan, in *Array, out *Array) {
+	if n <= 0 {
+		panic("fftw32: n must be > 0")
+	}
+	in = NewArray(n)
+	out = NewArray(n)
+	p = NewPlan(in, out, dir, flag)
+	return p, in, out
+}
+
+// NewPlan returns a plan for the DFT of in, written to out.
+// It panics if the arrays are unsuitable; TryNewPlan returns an error instead.
+func NewPlan(in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan(in, out, dir, flag))
+}
+
+// 2D version of NewPlan.
+func NewPlan2(in, out *Array2, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan2(in, out, dir, flag))
+}
+
+// 3D version of NewPlan.
+func NewPlan3(in, out *Array3, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan3(in, out, dir, flag))
+}
+
+// N-dimensional version of NewPlan.
+func NewPlanN(in, out *ArrayN, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanN(in, out, dir, flag))
+}
+
+// TryNewPlan is the version of NewPlan that returns an error instead of panicking.
+//
+// It returns ErrEmpty for nil or empty arrays, ErrDimensionsMismatch if their
+// lengths differ, and ErrNoPlan for WisdomOnly, since the pure-Go backend has no
+// wisdom.
+func TryNewPlan(in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	if in.Len() == 0 {
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+	}
+	if in.Len() != out.Len() {
+		return nil, fmt.Errorf("%w: input length %d, output length %d", ErrDimensionsMismatch, in.Len(), out.Len())
+	}
+	return newDFTPlan([]int{in.Len()}, in.Elems, out.Elems, dir, flag)
+}
+
+// 2D version of TryNewPlan.
+func TryNewPlan2(in, out *Array2, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if in0 <= 0 || in1 <= 0 {
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+	}
+	if in0 != out0 || in1 != out1 {
+		return nil, fmt.Errorf("%w: input (%d,%d), output (%d,%d)", ErrDimensionsMismatch, in0, in1, out0, out1)
+	}
+	return newDFTPlan(in.N[:], in.Elems, out.Elems, dir, flag)
+}
+
+// 3D version of TryNewPlan.
+func TryNewPlan3(in, out *Array3, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+	}
+	if in0 != out0 || in1 != out1 || in2 != out2 {
+		return nil, fmt.Errorf("%w: input (%d,%d,%d), output (%d,%d,%d)", ErrDimensionsMismatch,
+			in0, in1, in2, out0, out1, out2)
+	}
+	return newDFTPlan(in.N[:], in.Elems, out.Elems, dir, flag)
+}
+
+// N-dimensional version of TryNewPlan.
+func TryNewPlanN(in, out *ArrayN, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	inDims := in.Dims()
+	outDims := out.Dims()
+	if len(inDims) == 0 {
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+	}
+	for _, d := range inDims {
+		if d <= 0 {
+			return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+		}
+	}
+	if !equalDims(inDims, outDims) {
+		return nil, fmt.Errorf("%w: input %v, output %v", ErrDimensionsMismatch, inDims, outDims)
+	}
+	return newDFTPlan(inDims, in.Elems, out.Elems, dir, flag)
+}
+
+// newDFTPlan returns a plan for the DFT of the complex arrays in and out with
+// dimensions dims.
+func newDFTPlan(dims []int, in, out []complex64, dir Direction, flag Flag) (*Plan, error) {
+	plan, err := newPlan(flag, dftPlan, shape(dims, false), shape(dims, false),
+		unsafe.Pointer(unsafe.SliceData(in)), unsafe.Pointer(unsafe.SliceData(out)))
+	if err != nil {
+		return nil, err
+	}
+	d := newDFTN(dims, dir)
+	n := prod(dims)
+	pool := complexPool(n)
+	plan.scaling = complexScaling(n, dir, out)
+	plan.run = func(in, out unsafe.Pointer) {
+		buf := pool.Get().(*[]complex128)
+		defer pool.Put(buf)
+		z := *buf
+		for i, x := range unsafe.Slice((*complex64)(in), n) {
+			z[i] = complex128(x)
+		}
+		d.transform(z)
+		y := unsafe.Slice((*complex64)(out), n)
+		for i := range y {
+			y[i] = complex64(z[i])
+		}
+	}
+	plan.desc = fmt.Sprintf("(dft %v %v)", dims, d)
+	plan.adds, plan.muls = d.flops()
+	return plan, nil
+}
+
+// newPlan returns a plan for the arrays at in and out, with the given layout,
+// or ErrNoPlan for WisdomOnly. The pure-Go backend does not depend on the
+// alignment of the arrays, but ExecuteOn checks it as with FFTW, so that code
+// behaves the same with both backends.
+func newPlan(flag Flag, kind planKind, inShape, outShape arrayShape, in, out unsafe.Pointer) (*Plan, error) {
+	if flag&WisdomOnly != 0 {
+		return nil, ErrNoPlan
+	}
+	return &Plan{
+		layout:  newLayout(flag, kind, inShape, outShape, []unsafe.Pointer{in}, []unsafe.Pointer{out}),
+		scaling: scaling{},
+		in:      in,
+		out:     out,
+		run:     nil,
+		desc:    "",
+		adds:    0,
+		muls:    0,
+	}, nil
+}
+
+// mustPlan panics if err is not nil, for the constructors that panic on error.
+func mustPlan(p *Plan, err error) *Plan {
+	if err != nil {
+		panic("fftw32: " + err.Error())
+	}
+	return p
+}
+
+func (p *Plan) Execute() *Plan {
+	if p.destroyed() {
+		panic("fftw32: plan has been destroyed")
+	}
+	p.run(p.in, p.out)
+	return p
+}
+
+// String returns a textual description of the plan, in the spirit of
+// fftw_sprint_plan.
+func (p *Plan) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	if p.destroyed() {
+		return "<destroyed fftw plan>"
+	}
+	return p.desc
+}
+
+func (p *Plan) Destroy() {
+	p.run = nil
+	p.in, p.out = nil, nil
+}
+
+// detach releases the arrays p was created for, which it must then only be
+// executed with ExecuteOn and its variants.
+func (p *Plan) detach() {
+	p.in, p.out = nil, nil
+	p.scaling = scaling{}
+}
+
+// destroyed reports whether p has been destroyed.
+func (p *Plan) destroyed() bool {
+	return p.run == nil
+}
+
+func (p *Plan) executeDFT(in, out buffer) error {
+	if err := p.check(dftPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	p.run(in.ptr, out.ptr)
+	return nil
+}
+
+func (p *Plan) executeR2C(in, out buffer) error {
+	if err := p.check(r2cPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	p.run(in.ptr, out.ptr)
+	return nil
+}
+
+func (p *Plan) executeC2R(in, out buffer) error {
+	if err := p.check(c2rPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	p.run(in.ptr, out.ptr)
+	return nil
+}
+
+func (p *Plan) executeR2R(in, out buffer) error {
+	if err := p.check(r2rPlan, []buffer{in}, []buffer{out}); err != nil {
+		return err
+	}
+	p.run(in.ptr, out.ptr)
+	return nil
+}
+
+// alignmentOf returns the offset of p from a 16-byte boundary, the alignment
+// FFTW requires for SSE2.
+func alignmentOf(p unsafe.Pointer) int {
+	return int(uintptr(p) % 16)
+}
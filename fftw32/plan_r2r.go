@@ -0,0 +1,172 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewPlanR2R returns a plan for the real-to-real transform of the given kind.
+//
+// Real-to-real transforms are their own kind of plan, with no direction: the
+// inverse of each kind is another kind, up to a scale factor.
+func NewPlanR2R(in, out *RealArray, kind Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if in.Len() == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in.Len() != out.Len() {
+		panic("fftw32: input and output lengths must match")
+	}
+	checkKind(kind, in.Len())
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		numElems = C.int(in.Len())
+		inPtr    = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr   = (*C.float)(unsafe.Pointer(out.ptr()))
+		kind_    = C.fftwf_r2r_kind(kind)
+		flag_    = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape([]int{in.Len()}, false), shape([]int{out.Len()}, false),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_r2r_1d(numElems, inPtr, outPtr, kind_, flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2R2 returns a plan for the 2D real-to-real transform that applies
+// kind0 along the first dimension and kind1 along the second.
+func NewPlanR2R2(in, out *RealArray2, kind0, kind1 Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if in0 <= 0 || in1 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 {
+		panic("fftw32: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw32: real-to-real transforms do not support padded arrays")
+	}
+	checkKind(kind0, in0)
+	checkKind(kind1, in1)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(in0)
+		dim1   = C.int(in1)
+		inPtr  = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.float)(unsafe.Pointer(out.ptr()))
+		kind0_ = C.fftwf_r2r_kind(kind0)
+		kind1_ = C.fftwf_r2r_kind(kind1)
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape(in.N[:], in.Padded), shape(out.N[:], out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_r2r_2d(dim0, dim1, inPtr, outPtr, kind0_, kind1_, flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2R3 returns a plan for the 3D real-to-real transform that applies
+// kind0, kind1 and kind2 along the respective dimensions.
+func NewPlanR2R3(in, out *RealArray3, kind0, kind1, kind2 Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 || in2 != out2 {
+		panic("fftw32: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw32: real-to-real transforms do not support padded arrays")
+	}
+	checkKind(kind0, in0)
+	checkKind(kind1, in1)
+	checkKind(kind2, in2)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	var (
+		dim0   = C.int(in0)
+		dim1   = C.int(in1)
+		dim2   = C.int(in2)
+		inPtr  = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.float)(unsafe.Pointer(out.ptr()))
+		kind0_ = C.fftwf_r2r_kind(kind0)
+		kind1_ = C.fftwf_r2r_kind(kind1)
+		kind2_ = C.fftwf_r2r_kind(kind2)
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape(in.N[:], in.Padded), shape(out.N[:], out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_r2r_3d(dim0, dim1, dim2, inPtr, outPtr, kind0_, kind1_, kind2_, flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
+
+// NewPlanR2RN returns a plan for the N-dimensional real-to-real transform that
+// applies kinds[i] along dimension i.
+func NewPlanR2RN(in, out *RealArrayN, kinds []Kind, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	inDims := in.Dims()
+	if len(inDims) == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	for i := range inDims {
+		if inDims[i] <= 0 {
+			panic("fftw32: input and output must be non-empty")
+		}
+	}
+	if !equalDims(inDims, out.Dims()) {
+		panic("fftw32: input and output dimensions must match")
+	}
+	if in.Padded || out.Padded {
+		panic("fftw32: real-to-real transforms do not support padded arrays")
+	}
+	if len(kinds) != len(inDims) {
+		panic("fftw32: need one kind per dimension")
+	}
+	kinds_ := make([]C.fftwf_r2r_kind, len(kinds))
+	for i, k := range kinds {
+		checkKind(k, inDims[i])
+		kinds_[i] = C.fftwf_r2r_kind(k)
+	}
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.hold(unsafe.Pointer(in.ptr()))
+	plan.hold(unsafe.Pointer(out.ptr()))
+	numElems := cInts(inDims)
+	var (
+		rank   = C.int(len(inDims))
+		inPtr  = (*C.float)(unsafe.Pointer(in.ptr()))
+		outPtr = (*C.float)(unsafe.Pointer(out.ptr()))
+		flag_  = cFlag(flag)
+	)
+	plan.layout = newLayout(flag, r2rPlan, shape(in.N, in.Padded), shape(out.N, out.Padded),
+		[]unsafe.Pointer{unsafe.Pointer(in.ptr())}, []unsafe.Pointer{unsafe.Pointer(out.ptr())})
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_r2r(rank, &numElems[0], inPtr, outPtr, &kinds_[0], flag_)
+	unlockPlanner(flag)
+	return mustPlan(plan.finish())
+}
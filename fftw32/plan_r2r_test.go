@@ -0,0 +1,132 @@
+package fftw32
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPlanR2RGuards(t *testing.T) {
+	t.Parallel()
+
+	var nilArray *RealArray
+
+	expectPanic(t, "nil input", func() {
+		NewPlanR2R(nilArray, NewRealArray(1), R2HC, Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanR2R(NewRealArray(0), NewRealArray(0), R2HC, Estimate)
+	})
+
+	expectPanic(t, "length mismatch", func() {
+		NewPlanR2R(NewRealArray(4), NewRealArray(5), R2HC, Estimate)
+	})
+
+	expectPanic(t, "unknown kind", func() {
+		NewPlanR2R(NewRealArray(4), NewRealArray(4), Kind(42), Estimate)
+	})
+
+	expectPanic(t, "REDFT00 of one element", func() {
+		NewPlanR2R(NewRealArray(1), NewRealArray(1), REDFT00, Estimate)
+	})
+
+	expectPanic(t, "padded", func() {
+		NewPlanR2R2(NewRealArray2Padded(2, 2), NewRealArray2(2, 2), DHT, DHT, Estimate)
+	})
+}
+
+func TestDCT(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+
+	src := NewRealArray(n)
+	for i := range src.Elems {
+		src.Elems[i] = float32(i*i) - 3
+	}
+
+	got := DCT(src)
+	for k := range n {
+		var want float32
+		for j := range n {
+			want += 2 * src.Elems[j] * float32(math.Cos(math.Pi*(float64(j)+0.5)*float64(k)/n))
+		}
+
+		testNearlyEqual(t, got.Elems[k], want)
+	}
+}
+
+func TestRealToRealRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1, 5, 16} {
+		src := NewRealArray(n)
+		for i := range src.Elems {
+			src.Elems[i] = float32(math.Sin(float64(3*i))) + 1
+		}
+
+		for name, back := range map[string]*RealArray{
+			"DCT": IDCT(DCT(src)),
+			"DST": IDST(DST(src)),
+		} {
+			for i := range src.Elems {
+				if math.Abs(float64(back.Elems[i]-src.Elems[i])) > nearlyEqualEpsilon {
+					t.Fatalf("%s n=%d: at %d want %f, got %f", name, n, i, src.Elems[i], back.Elems[i])
+				}
+			}
+		}
+	}
+}
+
+func TestNewPlanR2RKinds(t *testing.T) {
+	t.Parallel()
+
+	// Each pair is a transform and its inverse, up to the logical size.
+	pairs := [][2]Kind{
+		{R2HC, HC2R}, {DHT, DHT},
+		{REDFT00, REDFT00}, {REDFT10, REDFT01}, {REDFT11, REDFT11},
+		{RODFT00, RODFT00}, {RODFT10, RODFT01}, {RODFT11, RODFT11},
+	}
+
+	const n = 6
+
+	for _, pair := range pairs {
+		src := NewRealArray(n)
+		for i := range src.Elems {
+			src.Elems[i] = float32(i%4) + 0.25
+		}
+
+		tmp := NewRealArray(n)
+		NewPlanR2R(src, tmp, pair[0], Estimate).Execute().Destroy()
+
+		out := NewRealArray(n)
+		NewPlanR2R(tmp, out, pair[1], Estimate).Execute().Destroy()
+
+		scale := float32(pair[0].logicalSize(n))
+		for i := range src.Elems {
+			testNearlyEqual(t, out.Elems[i], scale*src.Elems[i])
+		}
+	}
+}
+
+func TestNewPlanR2RMultiDim(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1, n2 = 3, 4, 5
+
+	src := NewRealArray3(n0, n1, n2)
+	for i := range src.Elems {
+		src.Elems[i] = float32(i % 9)
+	}
+
+	tmp := NewRealArray3(n0, n1, n2)
+	NewPlanR2R3(src, tmp, REDFT10, RODFT10, DHT, Estimate).Execute().Destroy()
+
+	out := NewRealArray3(n0, n1, n2)
+	NewPlanR2R3(tmp, out, REDFT01, RODFT01, DHT, Estimate).Execute().Destroy()
+
+	scale := float32(2 * n0 * 2 * n1 * n2)
+	for i := range src.Elems {
+		testNearlyEqual(t, out.Elems[i], scale*src.Elems[i])
+	}
+}
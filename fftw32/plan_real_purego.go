@@ -0,0 +1,275 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// NewPlanR2C returns a plan for the forward transform of the real signal in.
+//
+// A real signal of length n has a Hermitian spectrum, so only its n/2+1
+// non-negative frequency terms are computed; out must have that length.
+func NewPlanR2C(in *RealArray, out *Array, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if in.Len() == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if out.Len() != in.Len()/2+1 {
+		panic("fftw32: output length must be n/2+1")
+	}
+	return mustPlan(newR2CPlan([]int{in.Len()}, false, in.Elems, out.Elems, flag))
+}
+
+// NewPlanC2R returns a plan for the backward transform of the n/2+1 element
+// Hermitian half-spectrum in to the real signal out of length n.
+//
+// The pure-Go backend leaves the input intact, but FFTW overwrites it, so
+// portable code should not rely on it.
+func NewPlanC2R(in *Array, out *RealArray, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if out.Len() == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in.Len() != out.Len()/2+1 {
+		panic("fftw32: input length must be n/2+1")
+	}
+	return mustPlan(newC2RPlan([]int{out.Len()}, false, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2C2 returns a plan for the forward transform of the n0 x n1 real array in.
+//
+// The output holds the n0 x (n1/2+1) non-redundant elements of the spectrum.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2C2(in *RealArray2, out *Array2, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if in0 <= 0 || in1 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if out0 != in0 || out1 != in1/2+1 {
+		panic("fftw32: output dimensions must be n0 x (n1/2+1)")
+	}
+	realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	return mustPlan(newR2CPlan(in.N[:], in.Padded, in.Elems, out.Elems, flag))
+}
+
+// NewPlanC2R2 returns a plan for the backward transform of the n0 x (n1/2+1)
+// half-spectrum in to the n0 x n1 real array out.
+//
+// A padded output may be transformed in place, using out.Complex() as the input.
+func NewPlanC2R2(in *Array2, out *RealArray2, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1 := in.Dims()
+	out0, out1 := out.Dims()
+	if out0 <= 0 || out1 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1/2+1 {
+		panic("fftw32: input dimensions must be n0 x (n1/2+1)")
+	}
+	realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	return mustPlan(newC2RPlan(out.N[:], out.Padded, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2C3 returns a plan for the forward transform of the n0 x n1 x n2 real array in.
+//
+// The output holds the n0 x n1 x (n2/2+1) non-redundant elements of the spectrum.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2C3(in *RealArray3, out *Array3, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if in0 <= 0 || in1 <= 0 || in2 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if out0 != in0 || out1 != in1 || out2 != in2/2+1 {
+		panic("fftw32: output dimensions must be n0 x n1 x (n2/2+1)")
+	}
+	realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	return mustPlan(newR2CPlan(in.N[:], in.Padded, in.Elems, out.Elems, flag))
+}
+
+// NewPlanC2R3 returns a plan for the backward transform of the n0 x n1 x (n2/2+1)
+// half-spectrum in to the n0 x n1 x n2 real array out.
+//
+// A padded output may be transformed in place, using out.Complex() as the input.
+func NewPlanC2R3(in *Array3, out *RealArray3, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	in0, in1, in2 := in.Dims()
+	out0, out1, out2 := out.Dims()
+	if out0 <= 0 || out1 <= 0 || out2 <= 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	if in0 != out0 || in1 != out1 || in2 != out2/2+1 {
+		panic("fftw32: input dimensions must be n0 x n1 x (n2/2+1)")
+	}
+	realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	return mustPlan(newC2RPlan(out.N[:], out.Padded, in.Elems, out.Elems, flag))
+}
+
+// NewPlanR2CN returns a plan for the forward transform of the real array in.
+//
+// The output has the dimensions of in, except that the last one is n/2+1.
+// A padded input may be transformed in place, using in.Complex() as the output.
+func NewPlanR2CN(in *RealArrayN, out *ArrayN, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	inDims := in.Dims()
+	if len(inDims) == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	for i := range inDims {
+		if inDims[i] <= 0 {
+			panic("fftw32: input and output must be non-empty")
+		}
+	}
+	if !equalDims(out.Dims(), halfDims(inDims)) {
+		panic("fftw32: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	realInPlace(in.Padded, unsafe.Pointer(in.ptr()), unsafe.Pointer(out.ptr()))
+	return mustPlan(newR2CPlan(inDims, in.Padded, in.Elems, out.Elems, flag))
+}
+
+// NewPlanC2RN returns a plan for the backward transform of the half-spectrum in
+// to the real array out.
+//
+// The input has the dimensions of out, except that the last one is n/2+1.
+// A padded output may be transformed in place, using out.Complex() as the input.
+func NewPlanC2RN(in *ArrayN, out *RealArrayN, flag Flag) *Plan {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	outDims := out.Dims()
+	if len(outDims) == 0 {
+		panic("fftw32: input and output must be non-empty")
+	}
+	for i := range outDims {
+		if outDims[i] <= 0 {
+			panic("fftw32: input and output must be non-empty")
+		}
+	}
+	if !equalDims(in.Dims(), halfDims(outDims)) {
+		panic("fftw32: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	realInPlace(out.Padded, unsafe.Pointer(out.ptr()), unsafe.Pointer(in.ptr()))
+	return mustPlan(newC2RPlan(outDims, out.Padded, in.Elems, out.Elems, flag))
+}
+
+// newR2CPlan returns a plan for the forward transform of the real array in with
+// dimensions dims, computed as a complex DFT of the whole array.
+func newR2CPlan(dims []int, padded bool, in []float32, out []complex64, flag Flag) (*Plan, error) {
+	d := newDFTN(dims, Forward)
+	size, last := prod(dims), dims[len(dims)-1]
+	rows, rl, half := size/last, rowLen(last, padded), last/2+1
+	pool := complexPool(size)
+	plan, err := newPlan(flag, r2cPlan, shape(dims, padded), shape(halfDims(dims), false),
+		unsafe.Pointer(unsafe.SliceData(in)), unsafe.Pointer(unsafe.SliceData(out)))
+	if err != nil {
+		return nil, err
+	}
+	plan.scaling = complexScaling(size, Forward, out)
+	plan.run = func(in, out unsafe.Pointer) {
+		buf := pool.Get().(*[]complex128)
+		defer pool.Put(buf)
+		x := unsafe.Slice((*float32)(in), rows*rl)
+		y := unsafe.Slice((*complex64)(out), rows*half)
+		z := *buf
+		for r := range rows {
+			for k := range last {
+				z[r*last+k] = complex(float64(x[r*rl+k]), 0)
+			}
+		}
+		d.transform(z)
+		for r := range rows {
+			for k := range half {
+				y[r*half+k] = complex64(z[r*last+k])
+			}
+		}
+	}
+	plan.desc = fmt.Sprintf("(rdft-r2c %v %v)", dims, d)
+	plan.adds, plan.muls = d.flops()
+	return plan, nil
+}
+
+// newC2RPlan returns a plan for the backward transform of the half-spectrum in
+// to the real array out with dimensions dims. The redundant half of the
+// spectrum is restored from Hermitian symmetry before a complex DFT.
+func newC2RPlan(dims []int, padded bool, in []complex64, out []float32, flag Flag) (*Plan, error) {
+	d := newDFTN(dims, Backward)
+	size, last := prod(dims), dims[len(dims)-1]
+	rows, rl, half := size/last, rowLen(last, padded), last/2+1
+	mirror := mirrorRows(dims[:len(dims)-1])
+	pool := complexPool(size)
+	plan, err := newPlan(flag, c2rPlan, shape(halfDims(dims), false), shape(dims, padded),
+		unsafe.Pointer(unsafe.SliceData(in)), unsafe.Pointer(unsafe.SliceData(out)))
+	if err != nil {
+		return nil, err
+	}
+	plan.scaling = realScaling(size, out)
+	plan.run = func(in, out unsafe.Pointer) {
+		buf := pool.Get().(*[]complex128)
+		defer pool.Put(buf)
+		x := unsafe.Slice((*complex64)(in), rows*half)
+		y := unsafe.Slice((*float32)(out), rows*rl)
+		z := *buf
+		for r := range rows {
+			for k := range half {
+				z[r*last+k] = complex128(x[r*half+k])
+			}
+			for k := half; k < last; k++ {
+				z[r*last+k] = conj(complex128(x[mirror[r]*half+last-k]))
+			}
+		}
+		d.transform(z)
+		for r := range rows {
+			for k := range last {
+				y[r*rl+k] = float32(real(z[r*last+k]))
+			}
+		}
+	}
+	plan.desc = fmt.Sprintf("(rdft-c2r %v %v)", dims, d)
+	plan.adds, plan.muls = d.flops()
+	return plan, nil
+}
+
+// mirrorRows returns, for each row index of an array whose leading dimensions
+// are dims, the index of the row at the negated position, modulo dims.
+func mirrorRows(dims []int) []int {
+	mirror := make([]int, prod(dims))
+	for r := range mirror {
+		rest, stride := r, 1
+		for i := len(dims) - 1; i >= 0; i-- {
+			j := rest % dims[i]
+			rest /= dims[i]
+			mirror[r] += (dims[i] - j) % dims[i] * stride
+			stride *= dims[i]
+		}
+	}
+	return mirror
+}
+
+// complexPool returns a pool of scratch arrays of n elements, so that plans can
+// be executed concurrently.
+func complexPool(n int) *sync.Pool {
+	return &sync.Pool{New: func() any {
+		s := make([]complex128, n)
+		return &s
+	}}
+}
@@ -0,0 +1,309 @@
+//go:build cgo && !purego
+
+package fftw32
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewPlanSplit returns a plan for the DFT of the split array in, written to out.
+//
+// Split plans can be reused on other planar buffers of the same size with
+// ExecuteSplit.
+func NewPlanSplit(in, out *SplitArray, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplit(in, out, dir, flag))
+}
+
+// TryNewPlanSplit is the version of NewPlanSplit that returns ErrNoPlan instead
+// of panicking if FFTW cannot create the plan. Invalid arguments still panic.
+func TryNewPlanSplit(in, out *SplitArray, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if in.Len() != out.Len() {
+		panic("fftw32: input and output lengths must match")
+	}
+	return planSplitDFT([]int{in.Len()}, in.Re, in.Im, out.Re, out.Im, dir, flag)
+}
+
+// 2D version of NewPlanSplit.
+func NewPlanSplit2(in, out *SplitArray2, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplit2(in, out, dir, flag))
+}
+
+// 2D version of TryNewPlanSplit.
+func TryNewPlanSplit2(in, out *SplitArray2, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if in.N != out.N {
+		panic("fftw32: input and output dimensions must match")
+	}
+	return planSplitDFT(in.N[:], in.Re, in.Im, out.Re, out.Im, dir, flag)
+}
+
+// N-dimensional version of NewPlanSplit.
+func NewPlanSplitN(in, out *SplitArrayN, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitN(in, out, dir, flag))
+}
+
+// N-dimensional version of TryNewPlanSplit.
+func TryNewPlanSplitN(in, out *SplitArrayN, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if !equalDims(in.N, out.N) {
+		panic("fftw32: input and output dimensions must match")
+	}
+	return planSplitDFT(in.N, in.Re, in.Im, out.Re, out.Im, dir, flag)
+}
+
+// NewPlanSplitR2C returns a plan for the forward transform of the real signal in
+// to the n/2+1 element split half-spectrum out.
+func NewPlanSplitR2C(in *RealArray, out *SplitArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2C(in, out, flag))
+}
+
+// TryNewPlanSplitR2C is the version of NewPlanSplitR2C that returns ErrNoPlan
+// instead of panicking if FFTW cannot create the plan. Invalid arguments still
+// panic.
+func TryNewPlanSplitR2C(in *RealArray, out *SplitArray, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if out.Len() != in.Len()/2+1 {
+		panic("fftw32: output length must be n/2+1")
+	}
+	return planSplitR2C([]int{in.Len()}, false, in.Elems, out.Re, out.Im, flag)
+}
+
+// 2D version of NewPlanSplitR2C. The input may be padded.
+func NewPlanSplitR2C2(in *RealArray2, out *SplitArray2, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2C2(in, out, flag))
+}
+
+// 2D version of TryNewPlanSplitR2C. The input may be padded.
+func TryNewPlanSplitR2C2(in *RealArray2, out *SplitArray2, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if !equalDims(out.N[:], halfDims(in.N[:])) {
+		panic("fftw32: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	return planSplitR2C(in.N[:], in.Padded, in.Elems, out.Re, out.Im, flag)
+}
+
+// N-dimensional version of NewPlanSplitR2C. The input may be padded.
+func NewPlanSplitR2CN(in *RealArrayN, out *SplitArrayN, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitR2CN(in, out, flag))
+}
+
+// N-dimensional version of TryNewPlanSplitR2C. The input may be padded.
+func TryNewPlanSplitR2CN(in *RealArrayN, out *SplitArrayN, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if !equalDims(out.N, halfDims(in.N)) {
+		panic("fftw32: output dimensions must match input, with n/2+1 in the last dimension")
+	}
+	return planSplitR2C(in.N, in.Padded, in.Elems, out.Re, out.Im, flag)
+}
+
+// NewPlanSplitC2R returns a plan for the backward transform of the n/2+1 element
+// split half-spectrum in to the real signal out of length n.
+//
+// Beware that FFTW overwrites the input of a complex-to-real transform when the
+// plan is executed.
+func NewPlanSplitC2R(in *SplitArray, out *RealArray, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2R(in, out, flag))
+}
+
+// TryNewPlanSplitC2R is the version of NewPlanSplitC2R that returns ErrNoPlan
+// instead of panicking if FFTW cannot create the plan. Invalid arguments still
+// panic.
+func TryNewPlanSplitC2R(in *SplitArray, out *RealArray, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if in.Len() != out.Len()/2+1 {
+		panic("fftw32: input length must be n/2+1")
+	}
+	return planSplitC2R([]int{out.Len()}, false, in.Re, in.Im, out.Elems, flag)
+}
+
+// 2D version of NewPlanSplitC2R. The output may be padded.
+func NewPlanSplitC2R2(in *SplitArray2, out *RealArray2, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2R2(in, out, flag))
+}
+
+// 2D version of TryNewPlanSplitC2R. The output may be padded.
+func TryNewPlanSplitC2R2(in *SplitArray2, out *RealArray2, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if !equalDims(in.N[:], halfDims(out.N[:])) {
+		panic("fftw32: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	return planSplitC2R(out.N[:], out.Padded, in.Re, in.Im, out.Elems, flag)
+}
+
+// N-dimensional version of NewPlanSplitC2R. The output may be padded.
+func NewPlanSplitC2RN(in *SplitArrayN, out *RealArrayN, flag Flag) *Plan {
+	return mustPlan(TryNewPlanSplitC2RN(in, out, flag))
+}
+
+// N-dimensional version of TryNewPlanSplitC2R. The output may be padded.
+func TryNewPlanSplitC2RN(in *SplitArrayN, out *RealArrayN, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		panic("fftw32: input and output must be non-nil")
+	}
+	if !equalDims(in.N, halfDims(out.N)) {
+		panic("fftw32: input dimensions must match output, with n/2+1 in the last dimension")
+	}
+	return planSplitC2R(out.N, out.Padded, in.Re, in.Im, out.Elems, flag)
+}
+
+// ExecuteSplit executes the split DFT plan p on the planar buffers ri, ii (input)
+// and ro, io (output) instead of the arrays it was created for.
+//
+// The same requirements as for ExecuteOn apply, except that the buffers only
+// need to be at least as long as the planned arrays.
+func (p *Plan) ExecuteSplit(ri, ii, ro, io []float32) error {
+	in := []buffer{planarBuffer(ri), planarBuffer(ii)}
+	out := []buffer{planarBuffer(ro), planarBuffer(io)}
+	if err := p.check(splitDFTPlan, in, out); err != nil {
+		return err
+	}
+	if p.layout.backward {
+		ri, ii, ro, io = ii, ri, io, ro
+	}
+	C.fftwf_execute_split_dft(p.fftwP, cDouble(ri), cDouble(ii), cDouble(ro), cDouble(io))
+	return nil
+}
+
+// ExecuteSplitR2C executes the split real-to-complex plan p on the real buffer
+// in and the planar output buffers ro and io.
+// The same requirements as for ExecuteSplit apply.
+func (p *Plan) ExecuteSplitR2C(in, ro, io []float32) error {
+	out := []buffer{planarBuffer(ro), planarBuffer(io)}
+	if err := p.check(splitR2CPlan, []buffer{planarBuffer(in)}, out); err != nil {
+		return err
+	}
+	C.fftwf_execute_split_dft_r2c(p.fftwP, cDouble(in), cDouble(ro), cDouble(io))
+	return nil
+}
+
+// ExecuteSplitC2R executes the split complex-to-real plan p on the planar input
+// buffers ri and ii and the real buffer out, overwriting the input.
+// The same requirements as for ExecuteSplit apply.
+func (p *Plan) ExecuteSplitC2R(ri, ii, out []float32) error {
+	in := []buffer{planarBuffer(ri), planarBuffer(ii)}
+	if err := p.check(splitC2RPlan, in, []buffer{planarBuffer(out)}); err != nil {
+		return err
+	}
+	C.fftwf_execute_split_dft_c2r(p.fftwP, cDouble(ri), cDouble(ii), cDouble(out))
+	return nil
+}
+
+func planSplitDFT(n []int, ri, ii, ro, io []float32, dir Direction, flag Flag) (*Plan, error) {
+	dims := splitIODims(n, n, n)
+	if len(ri) != len(ii) || len(ro) != len(io) {
+		panic("fftw32: real and imaginary parts must have the same length")
+	}
+	validateGuru(dims, nil, len(ri), len(ro), false, false, 1)
+	cDims := cIODims64(dims)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.layout = newLayout(flag, splitDFTPlan, shape(n, false), shape(n, false),
+		[]unsafe.Pointer{slicePointer(ri), slicePointer(ii)}, []unsafe.Pointer{slicePointer(ro), slicePointer(io)})
+	plan.layout.backward = dir == Backward
+	for _, x := range [][]float32{ri, ii, ro, io} {
+		plan.hold(unsafe.Pointer(&x[0]))
+	}
+	// FFTW's split interface has no sign; the backward transform swaps the real
+	// and imaginary parts of both the input and the output.
+	if plan.layout.backward {
+		ri, ii, ro, io = ii, ri, io, ro
+	}
+	flag_ := cFlag(flag)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_guru64_split_dft(C.int(len(n)), &cDims[0], 0, nil,
+		cDouble(ri), cDouble(ii), cDouble(ro), cDouble(io), flag_)
+	unlockPlanner(flag)
+	return plan.finish()
+}
+
+func planSplitR2C(n []int, padded bool, in, ro, io []float32, flag Flag) (*Plan, error) {
+	half := halfDims(n)
+	dims := splitIODims(n, realDims(n, padded), half)
+	if len(ro) != len(io) {
+		panic("fftw32: real and imaginary parts must have the same length")
+	}
+	validateGuru(dims, nil, len(in), len(ro), false, true, 1)
+	cDims := cIODims64(dims)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.layout = newLayout(flag, splitR2CPlan, shape(n, padded), shape(half, false),
+		[]unsafe.Pointer{slicePointer(in)}, []unsafe.Pointer{slicePointer(ro), slicePointer(io)})
+	for _, x := range [][]float32{in, ro, io} {
+		plan.hold(unsafe.Pointer(&x[0]))
+	}
+	flag_ := cFlag(flag)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_guru64_split_dft_r2c(C.int(len(n)), &cDims[0], 0, nil,
+		cDouble(in), cDouble(ro), cDouble(io), flag_)
+	unlockPlanner(flag)
+	return plan.finish()
+}
+
+func planSplitC2R(n []int, padded bool, ri, ii, out []float32, flag Flag) (*Plan, error) {
+	half := halfDims(n)
+	dims := splitIODims(n, half, realDims(n, padded))
+	if len(ri) != len(ii) {
+		panic("fftw32: real and imaginary parts must have the same length")
+	}
+	validateGuru(dims, nil, len(ri), len(out), true, false, 1)
+	cDims := cIODims64(dims)
+	plan := &Plan{fftwP: nil, pin: runtime.Pinner{}}
+	plan.layout = newLayout(flag, splitC2RPlan, shape(half, false), shape(n, padded),
+		[]unsafe.Pointer{slicePointer(ri), slicePointer(ii)}, []unsafe.Pointer{slicePointer(out)})
+	for _, x := range [][]float32{ri, ii, out} {
+		plan.hold(unsafe.Pointer(&x[0]))
+	}
+	flag_ := cFlag(flag)
+	lockPlanner(flag)
+	plan.fftwP = C.fftwf_plan_guru64_split_dft_c2r(C.int(len(n)), &cDims[0], 0, nil,
+		cDouble(ri), cDouble(ii), cDouble(out), flag_)
+	unlockPlanner(flag)
+	return plan.finish()
+}
+
+// splitIODims returns the guru dimensions of a transform of logical size n
+// between contiguous row-major arrays with memory dimensions in and out.
+func splitIODims(n, in, out []int) []IODim64 {
+	if len(n) == 0 {
+		panic("fftw32: transform must have at least 1 dimension(s)")
+	}
+	dims := make([]IODim64, len(n))
+	is, os := 1, 1
+	for d := len(n) - 1; d >= 0; d-- {
+		dims[d] = IODim64{N: n[d], Is: is, Os: os}
+		is *= in[d]
+		os *= out[d]
+	}
+	return dims
+}
+
+func slicePointer(x []float32) unsafe.Pointer {
+	return unsafe.Pointer(unsafe.SliceData(x))
+}
+
+// cDouble returns a C pointer to the first element of x, or nil if x is empty.
+func cDouble(x []float32) *C.float {
+	if len(x) == 0 {
+		return nil
+	}
+	return (*C.float)(unsafe.Pointer(&x[0]))
+}
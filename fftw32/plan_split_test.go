@@ -0,0 +1,156 @@
+//go:build cgo && !purego
+
+package fftw32
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewPlanSplitGuards(t *testing.T) {
+	t.Parallel()
+
+	var nilSplit *SplitArray
+
+	expectPanic(t, "nil input", func() {
+		NewPlanSplit(nilSplit, NewSplitArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "empty input", func() {
+		NewPlanSplit(NewSplitArray(0), NewSplitArray(0), Forward, Estimate)
+	})
+
+	expectPanic(t, "length mismatch", func() {
+		NewPlanSplit(NewSplitArray(4), NewSplitArray(5), Forward, Estimate)
+	})
+
+	expectPanic(t, "short imaginary part", func() {
+		in := &SplitArray{Re: make([]float32, 4), Im: make([]float32, 3)}
+		NewPlanSplit(in, NewSplitArray(4), Forward, Estimate)
+	})
+
+	expectPanic(t, "r2c output length", func() {
+		NewPlanSplitR2C(NewRealArray(8), NewSplitArray(8), Estimate)
+	})
+
+	expectPanic(t, "c2r input dims", func() {
+		NewPlanSplitC2R2(NewSplitArray2(4, 6), NewRealArray2(4, 6), Estimate)
+	})
+
+	// There is no wisdom for this unusual size, so FFTW returns a NULL plan.
+	in, out := NewSplitArray(1001), NewSplitArray(1001)
+	if _, err := TryNewPlanSplit(in, out, Forward, Exhaustive|WisdomOnly); !errors.Is(err, ErrNoPlan) {
+		t.Errorf("expected ErrNoPlan, got %v", err)
+	}
+}
+
+func TestNewPlanSplitMatchesNewPlan(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 6
+
+	for _, dir := range []Direction{Forward, Backward} {
+		in := NewSplitArray2(n0, n1)
+		out := NewSplitArray2(n0, n1)
+		ref := NewArray2(n0, n1)
+
+		for i := range n0 {
+			for j := range n1 {
+				x := complex(float32(math.Sin(float64(i*n1+j))), float32(i-j))
+				in.Set(i, j, x)
+				ref.Set(i, j, x)
+			}
+		}
+
+		NewPlanSplit2(in, out, dir, Estimate).Execute().Destroy()
+		NewPlan2(ref, ref, dir, Estimate).Execute().Destroy()
+
+		for i := range n0 {
+			for j := range n1 {
+				testNearlyEqual(t, real(out.At(i, j)), real(ref.At(i, j)))
+				testNearlyEqual(t, imag(out.At(i, j)), imag(ref.At(i, j)))
+			}
+		}
+	}
+}
+
+func TestExecuteSplitOnNewBuffers(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	in := NewSplitArray(n)
+	out := NewSplitArray(n)
+
+	p := NewPlanSplit(in, out, Backward, Estimate)
+	defer p.Destroy()
+
+	// A cosine in planar buffers other than the planned ones.
+	other, res := NewSplitArray(n), NewSplitArray(n)
+	for i := range other.Re {
+		other.Re[i] = float32(math.Cos(float64(i) / n * math.Pi * 2))
+	}
+
+	if err := p.ExecuteSplit(other.Re, other.Im, res.Re, res.Im); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range res.Re {
+		want := float32(0)
+		if i == 1 || i == n-1 {
+			want = n / 2
+		}
+
+		testNearlyEqual(t, res.Re[i], want)
+		testNearlyEqual(t, res.Im[i], 0)
+	}
+
+	if err := p.ExecuteSplit(other.Re[:n-1], other.Im, res.Re, res.Im); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch, got %v", err)
+	}
+
+	if err := p.ExecuteSplit(other.Re, other.Im, other.Re, other.Im); !errors.Is(err, ErrInPlace) {
+		t.Fatalf("expected ErrInPlace, got %v", err)
+	}
+
+	if err := p.ExecuteSplitR2C(other.Re, res.Re, res.Im); !errors.Is(err, ErrPlanKind) {
+		t.Fatalf("expected ErrPlanKind, got %v", err)
+	}
+}
+
+func TestSplitR2CAndC2R(t *testing.T) {
+	t.Parallel()
+
+	const n0, n1 = 4, 5
+
+	src := NewRealArray2(n0, n1)
+	for i := range src.Elems {
+		src.Elems[i] = float32(math.Sin(float64(i))) - 0.25
+	}
+
+	want := RFFT2(src)
+
+	spec := NewSplitArray2(n0, n1/2+1)
+	NewPlanSplitR2C2(src, spec, Estimate).Execute().Destroy()
+
+	for i := range want.Elems {
+		testNearlyEqual(t, spec.Re[i], real(want.Elems[i]))
+		testNearlyEqual(t, spec.Im[i], imag(want.Elems[i]))
+	}
+
+	back := NewRealArray2Padded(n0, n1)
+
+	p := NewPlanSplitC2R2(spec, back, Estimate)
+	defer p.Destroy()
+
+	if err := p.ExecuteSplitC2R(spec.Re, spec.Im, back.Elems); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range n0 {
+		for j := range n1 {
+			testNearlyEqual(t, back.At(i, j), n0*n1*src.At(i, j))
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package fftw32
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func expectPanic(t *testing.T, name string, panicFn func()) {
 	t.Helper()
@@ -94,3 +98,55 @@ func TestNewPlan3Guards(t *testing.T) {
 		NewPlan3(NewArray3(1, 0, 1), NewArray3(1, 0, 1), Forward, Estimate)
 	})
 }
+
+func TestTryNewPlanErrors(t *testing.T) {
+	t.Parallel()
+
+	var nilArray *Array
+
+	if _, err := TryNewPlan(nilArray, NewArray(1), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Errorf("nil input: expected ErrEmpty, got %v", err)
+	}
+
+	if _, err := TryNewPlan(NewArray(0), NewArray(0), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Errorf("empty input: expected ErrEmpty, got %v", err)
+	}
+
+	if _, err := TryNewPlan2(NewArray2(2, 3), NewArray2(3, 2), Forward, Estimate); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Errorf("2D: expected ErrDimensionsMismatch, got %v", err)
+	}
+
+	if _, err := TryNewPlan3(NewArray3(2, 0, 3), NewArray3(2, 0, 3), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Errorf("3D: expected ErrEmpty, got %v", err)
+	}
+
+	// There is no wisdom for this unusual size, so FFTW returns a NULL plan.
+	in, out := NewArray3(7, 11, 13), NewArray3(7, 11, 13)
+	if _, err := TryNewPlan3(in, out, Forward, Exhaustive|WisdomOnly); !errors.Is(err, ErrNoPlan) {
+		t.Errorf("expected ErrNoPlan, got %v", err)
+	}
+
+	expectPanic(t, "NULL plan", func() {
+		NewPlan3(in, out, Forward, Exhaustive|WisdomOnly)
+	})
+}
+
+func TestTryNewPlan(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	signal := NewArray(n)
+	for i := range signal.Elems {
+		signal.Elems[i] = complex(float32(math.Cos(float64(i)/n*math.Pi*2)), 0)
+	}
+
+	p, err := TryNewPlan(signal, signal, Forward, Estimate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer p.Destroy()
+
+	p.Execute()
+	peakVerifier(t, signal.Elems)
+}
@@ -0,0 +1,27 @@
+//go:build !fftw_threads || !cgo || purego
+
+package fftw32
+
+// InitThreads returns ErrThreads, because the package was built without the
+// fftw_threads build tag.
+func InitThreads() error {
+	return ErrThreads
+}
+
+// PlanWithNThreads does nothing without the fftw_threads build tag.
+func PlanWithNThreads(n int) {
+	if n < 1 {
+		panic("fftw32: thread count must be >= 1")
+	}
+}
+
+// CleanupThreads does nothing without the fftw_threads build tag.
+func CleanupThreads() {}
+
+func lockPlanner(Flag) {
+	createDestroyMu.Lock()
+}
+
+func unlockPlanner(Flag) {
+	createDestroyMu.Unlock()
+}
@@ -0,0 +1,48 @@
+//go:build !cgo || purego
+
+package fftw32
+
+import "io"
+
+// ExportWisdom returns ErrWisdom, because the pure-Go backend has no wisdom.
+func ExportWisdom(w io.Writer) error {
+	return ErrWisdom
+}
+
+// ImportWisdom returns ErrWisdom, because the pure-Go backend has no wisdom.
+func ImportWisdom(r io.Reader) error {
+	return ErrWisdom
+}
+
+// ExportWisdomString returns an empty string, because the pure-Go backend has
+// no wisdom.
+func ExportWisdomString() string {
+	return ""
+}
+
+// ImportWisdomString returns ErrWisdom, because the pure-Go backend has no
+// wisdom.
+func ImportWisdomString(s string) error {
+	return ErrWisdom
+}
+
+// ExportWisdomFile returns ErrWisdom without creating the file, because the
+// pure-Go backend has no wisdom.
+func ExportWisdomFile(name string) error {
+	return ErrWisdom
+}
+
+// ImportWisdomFile returns ErrWisdom, because the pure-Go backend has no
+// wisdom.
+func ImportWisdomFile(name string) error {
+	return ErrWisdom
+}
+
+// ImportSystemWisdom returns ErrWisdom, because the pure-Go backend has no
+// wisdom.
+func ImportSystemWisdom() error {
+	return ErrWisdom
+}
+
+// ForgetWisdom does nothing, because the pure-Go backend has no wisdom.
+func ForgetWisdom() {}
@@ -0,0 +1,54 @@
+//go:build cgo && !purego
+
+package fftw32
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWisdomRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in, out := NewArray(64), NewArray(64)
+	NewPlan(in, out, Forward, Measure).Destroy()
+
+	var buf bytes.Buffer
+	if err := ExportWisdom(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "wisdom") {
+		t.Fatalf("unexpected wisdom %q", buf.String())
+	}
+
+	name := filepath.Join(t.TempDir(), "wisdom")
+	if err := ExportWisdomFile(name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ForgetWisdom()
+
+	if err := ImportWisdom(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := ImportWisdomFile(name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestImportWisdomErrors(t *testing.T) {
+	t.Parallel()
+
+	if err := ImportWisdomString("not wisdom"); !errors.Is(err, ErrWisdom) {
+		t.Fatalf("expected ErrWisdom, got %v", err)
+	}
+
+	if err := ImportWisdomFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
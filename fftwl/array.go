@@ -0,0 +1,242 @@
+//go:build cgo
+
+package fftwl
+
+import "math/big"
+
+// Data for a 1D signal, stored in long double precision.
+//
+// The zero value is not usable; create arrays with NewArray or NewArrayFrom.
+type Array struct {
+	s *storage
+}
+
+// Allocates memory using fftwl_malloc.
+func NewArray(n int) *Array {
+	return &Array{newStorage(n)}
+}
+
+// NewArrayFrom returns a new array holding the elements of x.
+func NewArrayFrom(x []complex128) *Array {
+	a := NewArray(len(x))
+	a.CopyFrom(x)
+	return a
+}
+
+func (a *Array) Len() int {
+	return a.s.n
+}
+
+// At returns element i rounded to complex128.
+func (a *Array) At(i int) complex128 {
+	return a.s.at(i)
+}
+
+func (a *Array) Set(i int, x complex128) {
+	a.s.set(i, x)
+}
+
+// AtBig returns the exact real and imaginary parts of element i, with
+// Precision bits of mantissa. A part that is NaN is returned as nil.
+func (a *Array) AtBig(i int) (re, im *big.Float) {
+	return a.s.atBig(i)
+}
+
+// SetBig sets element i to re + im i, rounded to long double. A nil part is
+// taken as zero.
+func (a *Array) SetBig(i int, re, im *big.Float) {
+	a.s.setBig(i, re, im)
+}
+
+// CopyFrom sets the elements of a to those of x, which must have the same length.
+func (a *Array) CopyFrom(x []complex128) {
+	a.s.copyFrom(x)
+}
+
+// Complex128 returns the elements of a rounded to complex128.
+func (a *Array) Complex128() []complex128 {
+	return a.s.complex128s()
+}
+
+// Free releases the memory of a, which must not be used afterwards.
+// Plans created for a keep the memory until they are destroyed.
+func (a *Array) Free() {
+	a.s.release()
+	a.s = newStorage(0)
+}
+
+// 2D version of Array.
+type Array2 struct {
+	n [2]int
+	s *storage
+}
+
+func NewArray2(n0, n1 int) *Array2 {
+	return &Array2{[...]int{n0, n1}, newStorage(n0 * n1)}
+}
+
+func (a *Array2) Dims() (int, int) {
+	return a.n[0], a.n[1]
+}
+
+func (a *Array2) At(i0, i1 int) complex128 {
+	return a.s.at(a.index(i0, i1))
+}
+
+func (a *Array2) Set(i0, i1 int, x complex128) {
+	a.s.set(a.index(i0, i1), x)
+}
+
+// AtBig is the 2D version of Array.AtBig.
+func (a *Array2) AtBig(i0, i1 int) (re, im *big.Float) {
+	return a.s.atBig(a.index(i0, i1))
+}
+
+// SetBig is the 2D version of Array.SetBig.
+func (a *Array2) SetBig(i0, i1 int, re, im *big.Float) {
+	a.s.setBig(a.index(i0, i1), re, im)
+}
+
+// CopyFrom sets the elements of a to those of x, in row-major order.
+func (a *Array2) CopyFrom(x []complex128) {
+	a.s.copyFrom(x)
+}
+
+// Complex128 returns the elements of a rounded to complex128, in row-major order.
+func (a *Array2) Complex128() []complex128 {
+	return a.s.complex128s()
+}
+
+// Free releases the memory of a, which must not be used afterwards.
+func (a *Array2) Free() {
+	a.s.release()
+	a.s = newStorage(0)
+}
+
+func (a *Array2) index(i0, i1 int) int {
+	if i0 < 0 || i0 >= a.n[0] || i1 < 0 || i1 >= a.n[1] {
+		panic("fftwl: index out of range")
+	}
+	return i1 + a.n[1]*i0
+}
+
+// 3D version of Array.
+type Array3 struct {
+	n [3]int
+	s *storage
+}
+
+func NewArray3(n0, n1, n2 int) *Array3 {
+	return &Array3{[...]int{n0, n1, n2}, newStorage(n0 * n1 * n2)}
+}
+
+func (a *Array3) Dims() (int, int, int) {
+	return a.n[0], a.n[1], a.n[2]
+}
+
+func (a *Array3) At(i0, i1, i2 int) complex128 {
+	return a.s.at(a.index(i0, i1, i2))
+}
+
+func (a *Array3) Set(i0, i1, i2 int, x complex128) {
+	a.s.set(a.index(i0, i1, i2), x)
+}
+
+// AtBig is the 3D version of Array.AtBig.
+func (a *Array3) AtBig(i0, i1, i2 int) (re, im *big.Float) {
+	return a.s.atBig(a.index(i0, i1, i2))
+}
+
+// SetBig is the 3D version of Array.SetBig.
+func (a *Array3) SetBig(i0, i1, i2 int, re, im *big.Float) {
+	a.s.setBig(a.index(i0, i1, i2), re, im)
+}
+
+// CopyFrom sets the elements of a to those of x, in row-major order.
+func (a *Array3) CopyFrom(x []complex128) {
+	a.s.copyFrom(x)
+}
+
+// Complex128 returns the elements of a rounded to complex128, in row-major order.
+func (a *Array3) Complex128() []complex128 {
+	return a.s.complex128s()
+}
+
+// Free releases the memory of a, which must not be used afterwards.
+func (a *Array3) Free() {
+	a.s.release()
+	a.s = newStorage(0)
+}
+
+func (a *Array3) index(i0, i1, i2 int) int {
+	if i0 < 0 || i0 >= a.n[0] || i1 < 0 || i1 >= a.n[1] || i2 < 0 || i2 >= a.n[2] {
+		panic("fftwl: index out of range")
+	}
+	return i2 + a.n[2]*(i1+a.n[1]*i0)
+}
+
+// N-dimensional version of Array.
+type ArrayN struct {
+	n []int
+	s *storage
+}
+
+func NewArrayN(dims []int) *ArrayN {
+	size := 1
+	for _, d := range dims {
+		size *= d
+	}
+	return &ArrayN{append([]int(nil), dims...), newStorage(size)}
+}
+
+func (a *ArrayN) Dims() []int {
+	return append([]int(nil), a.n...)
+}
+
+func (a *ArrayN) At(idx []int) complex128 {
+	return a.s.at(a.index(idx))
+}
+
+func (a *ArrayN) Set(idx []int, x complex128) {
+	a.s.set(a.index(idx), x)
+}
+
+// AtBig is the N-dimensional version of Array.AtBig.
+func (a *ArrayN) AtBig(idx []int) (re, im *big.Float) {
+	return a.s.atBig(a.index(idx))
+}
+
+// SetBig is the N-dimensional version of Array.SetBig.
+func (a *ArrayN) SetBig(idx []int, re, im *big.Float) {
+	a.s.setBig(a.index(idx), re, im)
+}
+
+// CopyFrom sets the elements of a to those of x, in row-major order.
+func (a *ArrayN) CopyFrom(x []complex128) {
+	a.s.copyFrom(x)
+}
+
+// Complex128 returns the elements of a rounded to complex128, in row-major order.
+func (a *ArrayN) Complex128() []complex128 {
+	return a.s.complex128s()
+}
+
+// Free releases the memory of a, which must not be used afterwards.
+func (a *ArrayN) Free() {
+	a.s.release()
+	a.s = newStorage(0)
+}
+
+func (a *ArrayN) index(idx []int) int {
+	if len(idx) != len(a.n) {
+		panic("fftwl: wrong number of indices")
+	}
+	i := 0
+	for d, n := range a.n {
+		if idx[d] < 0 || idx[d] >= n {
+			panic("fftwl: index out of range")
+		}
+		i = i*n + idx[d]
+	}
+	return i
+}
@@ -0,0 +1,83 @@
+//go:build cgo
+
+package fftwl
+
+// #include <fftw3.h>
+import "C"
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Direction int
+
+const (
+	Forward  = Direction(C.FFTW_FORWARD)
+	Backward = Direction(C.FFTW_BACKWARD)
+)
+
+// Flag holds the planner flags, which can be combined with |.
+//
+// They have the same meaning as in package fftw; in particular Measure, Patient
+// and Exhaustive overwrite the arrays during planning.
+type Flag uint
+
+const (
+	// Estimate picks a plan with a heuristic, without running any transforms.
+	Estimate = Flag(C.FFTW_ESTIMATE)
+	// Measure times several transforms to pick a fast plan. This is FFTW's default.
+	Measure = Flag(C.FFTW_MEASURE)
+	// Patient considers more algorithms than Measure, taking longer to plan.
+	Patient = Flag(C.FFTW_PATIENT)
+	// Exhaustive considers even more algorithms than Patient.
+	Exhaustive = Flag(C.FFTW_EXHAUSTIVE)
+	// WisdomOnly only creates a plan if wisdom for it is available.
+	WisdomOnly = Flag(C.FFTW_WISDOM_ONLY)
+
+	// DestroyInput allows an out-of-place plan to overwrite its input array
+	// when executed.
+	DestroyInput = Flag(C.FFTW_DESTROY_INPUT)
+	// PreserveInput forbids an out-of-place plan from overwriting its input
+	// array when executed.
+	PreserveInput = Flag(C.FFTW_PRESERVE_INPUT)
+	// Unaligned makes no assumption on the alignment of the arrays.
+	Unaligned = Flag(C.FFTW_UNALIGNED)
+	// ConserveMemory prefers plans that use less memory.
+	ConserveMemory = Flag(C.FFTW_CONSERVE_MEMORY)
+)
+
+//nolint:gochecknoglobals
+var flagNames = []struct {
+	flag Flag
+	name string
+}{
+	{Estimate, "Estimate"},
+	{Patient, "Patient"},
+	{Exhaustive, "Exhaustive"},
+	{WisdomOnly, "WisdomOnly"},
+	{DestroyInput, "DestroyInput"},
+	{PreserveInput, "PreserveInput"},
+	{Unaligned, "Unaligned"},
+	{ConserveMemory, "ConserveMemory"},
+}
+
+// String returns the flags of f joined by |, for example "Patient|DestroyInput".
+// Measure is shown when no other planning rigor is set.
+func (f Flag) String() string {
+	var names []string
+	if f&(Estimate|Patient|Exhaustive|WisdomOnly) == 0 {
+		names = append(names, "Measure")
+	}
+	rest := f
+	for _, fn := range flagNames {
+		if rest&fn.flag != 0 {
+			names = append(names, fn.name)
+			rest &^= fn.flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint(rest)))
+	}
+	return strings.Join(names, "|")
+}
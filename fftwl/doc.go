@@ -0,0 +1,31 @@
+/*
+Package fftwl is a cgo wrapper around the long double precision version of the
+Fastest Fourier Transform in the West.
+
+http://www.fftw.org/
+
+It has the same Array, Plan and FFT functions as package fftw, for computations
+that need more than float64 accuracy. Go has no long double type, so the
+elements of an array live in memory allocated by FFTW and are accessed through
+methods: At and Set convert from and to complex128, while AtBig and SetBig give
+the exact extended precision values as big.Float pairs.
+
+	x := fftwl.NewArrayFrom(samples)
+	defer x.Free()
+
+	xhat := fftwl.FFT(x)
+	defer xhat.Free()
+
+	re, im := xhat.AtBig(1)
+
+Arrays are freed by the garbage collector, but as it does not see the memory
+they hold, call Free when done with large arrays. A plan keeps the arrays it
+was created for alive until it is destroyed.
+
+Unlike packages fftw and fftw32, it has no pure-Go backend, so it is empty when
+built without cgo.
+
+Package fftwq is the quad precision (__float128) counterpart, available on
+Linux on x86.
+*/
+package fftwl
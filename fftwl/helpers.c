@@ -0,0 +1,63 @@
+#include <float.h>
+#include <stdio.h>
+#include <stdlib.h>
+
+#include "helpers.h"
+
+void *gofftwl_alloc(size_t n) { return fftwl_alloc_complex(n); }
+
+void gofftwl_free(void *a) { fftwl_free(a); }
+
+void gofftwl_get(const void *a, size_t i, double *re, double *im) {
+	const fftwl_complex *c = a;
+	*re = (double)c[i][0];
+	*im = (double)c[i][1];
+}
+
+void gofftwl_set(void *a, size_t i, double re, double im) {
+	fftwl_complex *c = a;
+	c[i][0] = re;
+	c[i][1] = im;
+}
+
+void gofftwl_get_all(const void *a, double *x, size_t n) {
+	const fftwl_complex *c = a;
+	for (size_t i = 0; i < n; i++) {
+		x[2 * i] = (double)c[i][0];
+		x[2 * i + 1] = (double)c[i][1];
+	}
+}
+
+void gofftwl_set_all(void *a, const double *x, size_t n) {
+	fftwl_complex *c = a;
+	for (size_t i = 0; i < n; i++) {
+		c[i][0] = x[2 * i];
+		c[i][1] = x[2 * i + 1];
+	}
+}
+
+// gofftwl_format writes part (0 for real, 1 for imaginary) of element i of a
+// as an exact hexadecimal floating-point string.
+int gofftwl_format(const void *a, size_t i, int part, char *buf, size_t size) {
+	const fftwl_complex *c = a;
+	return snprintf(buf, size, "%La", c[i][part]);
+}
+
+// gofftwl_parse sets part of element i of a to the value of s, and reports
+// whether all of s could be parsed.
+int gofftwl_parse(void *a, size_t i, int part, const char *s) {
+	fftwl_complex *c = a;
+	char *end;
+	long double x = strtold(s, &end);
+	if (end == s || *end != 0) {
+		return 0;
+	}
+	c[i][part] = x;
+	return 1;
+}
+
+int gofftwl_mant_dig(void) { return LDBL_MANT_DIG; }
+
+fftwl_plan gofftwl_plan_dft(int rank, const int *n, void *in, void *out, int sign, unsigned flags) {
+	return fftwl_plan_dft(rank, n, in, out, sign, flags);
+}
@@ -0,0 +1,17 @@
+// C helpers for package fftwl. Go cannot represent long double, so the
+// elements of the arrays are only ever touched from C.
+
+#include <stddef.h>
+#include <stdlib.h>
+#include <fftw3.h>
+
+void *gofftwl_alloc(size_t n);
+void gofftwl_free(void *a);
+void gofftwl_get(const void *a, size_t i, double *re, double *im);
+void gofftwl_set(void *a, size_t i, double re, double im);
+void gofftwl_get_all(const void *a, double *x, size_t n);
+void gofftwl_set_all(void *a, const double *x, size_t n);
+int gofftwl_format(const void *a, size_t i, int part, char *buf, size_t size);
+int gofftwl_parse(void *a, size_t i, int part, const char *s);
+int gofftwl_mant_dig(void);
+fftwl_plan gofftwl_plan_dft(int rank, const int *n, void *in, void *out, int sign, unsigned flags);
@@ -0,0 +1,9 @@
+//go:build cgo
+
+package fftwl
+
+// #cgo CFLAGS: -I/usr/local/include
+// #cgo darwin CFLAGS: -I/opt/homebrew/include
+// #cgo LDFLAGS: -L/usr/local/lib -lfftw3l -lm
+// #cgo darwin LDFLAGS: -L/opt/homebrew/lib
+import "C"
@@ -0,0 +1,171 @@
+//go:build cgo
+
+package fftwl
+
+// #include "helpers.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Creation and destruction of plans is not thread-safe in FFTW, see package fftw.
+//
+//nolint:gochecknoglobals
+var createDestroyMu sync.Mutex
+
+type Plan struct {
+	fftwP C.fftwl_plan
+	// The arrays the plan was created for, kept alive until it is destroyed.
+	in, out *storage
+}
+
+// NewPlan returns a plan for the DFT of in, written to out.
+// It panics if the arrays are unsuitable or FFTW cannot create the plan;
+// TryNewPlan returns an error instead.
+func NewPlan(in, out *Array, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan(in, out, dir, flag))
+}
+
+// 2D version of NewPlan.
+func NewPlan2(in, out *Array2, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan2(in, out, dir, flag))
+}
+
+// 3D version of NewPlan.
+func NewPlan3(in, out *Array3, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlan3(in, out, dir, flag))
+}
+
+// N-dimensional version of NewPlan.
+func NewPlanN(in, out *ArrayN, dir Direction, flag Flag) *Plan {
+	return mustPlan(TryNewPlanN(in, out, dir, flag))
+}
+
+// TryNewPlan is the version of NewPlan that returns an error instead of panicking.
+//
+// It returns ErrEmpty for nil or empty arrays, ErrDimensionsMismatch if their
+// lengths differ, and ErrNoPlan if FFTW cannot create the plan.
+func TryNewPlan(in, out *Array, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	return newPlan([]int{in.Len()}, []int{out.Len()}, in.s, out.s, dir, flag)
+}
+
+// 2D version of TryNewPlan.
+func TryNewPlan2(in, out *Array2, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	return newPlan(in.n[:], out.n[:], in.s, out.s, dir, flag)
+}
+
+// 3D version of TryNewPlan.
+func TryNewPlan3(in, out *Array3, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	return newPlan(in.n[:], out.n[:], in.s, out.s, dir, flag)
+}
+
+// N-dimensional version of TryNewPlan.
+func TryNewPlanN(in, out *ArrayN, dir Direction, flag Flag) (*Plan, error) {
+	if in == nil || out == nil {
+		return nil, fmt.Errorf("%w: input and output must be non-nil", ErrEmpty)
+	}
+	return newPlan(in.n, out.n, in.s, out.s, dir, flag)
+}
+
+func newPlan(inDims, outDims []int, in, out *storage, dir Direction, flag Flag) (*Plan, error) {
+	if len(inDims) == 0 || in.n == 0 {
+		return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+	}
+	for _, d := range inDims {
+		if d <= 0 {
+			return nil, fmt.Errorf("%w: input and output must be non-empty", ErrEmpty)
+		}
+	}
+	if !equalDims(inDims, outDims) || in.n != out.n {
+		return nil, fmt.Errorf("%w: input %v, output %v", ErrDimensionsMismatch, inDims, outDims)
+	}
+	n := make([]C.int, len(inDims))
+	for i, d := range inDims {
+		n[i] = C.int(d)
+	}
+	plan := &Plan{fftwP: nil, in: in, out: out}
+	createDestroyMu.Lock()
+	plan.fftwP = C.gofftwl_plan_dft(C.int(len(n)), &n[0], in.ptr, out.ptr, C.int(dir), C.uint(flag))
+	if plan.fftwP != nil {
+		in.retain()
+		out.retain()
+	}
+	createDestroyMu.Unlock()
+
+	if plan.fftwP == nil {
+		return nil, ErrNoPlan
+	}
+	runtime.SetFinalizer(plan, planFinalizer)
+	return plan, nil
+}
+
+// mustPlan panics if err is not nil, for the constructors that panic on error.
+func mustPlan(p *Plan, err error) *Plan {
+	if err != nil {
+		panic("fftwl: " + err.Error())
+	}
+	return p
+}
+
+func (p *Plan) Execute() *Plan {
+	C.fftwl_execute(p.fftwP)
+	return p
+}
+
+// String returns FFTW's textual description of the plan.
+//
+// This uses fftwl_sprint_plan under the hood.
+func (p *Plan) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	if p.fftwP == nil {
+		return "<destroyed fftwl plan>"
+	}
+	cstr := C.fftwl_sprint_plan(p.fftwP)
+	if cstr == nil {
+		return ""
+	}
+	defer C.fftwl_free(unsafe.Pointer(cstr))
+	return C.GoString(cstr)
+}
+
+func (p *Plan) Destroy() {
+	createDestroyMu.Lock()
+	if p.fftwP != nil {
+		C.fftwl_destroy_plan(p.fftwP)
+		p.in.unretain()
+		p.out.unretain()
+	}
+	p.fftwP = nil
+	createDestroyMu.Unlock()
+}
+
+func planFinalizer(p *Plan) {
+	p.Destroy()
+}
+
+func equalDims(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+/*
+Package fftwq is a cgo wrapper around the quad precision (__float128) version of
+the Fastest Fourier Transform in the West, which uses libquadmath.
+
+http://www.fftw.org/
+
+It is only available with cgo on Linux on x86, where GCC provides __float128,
+and has the same API as package fftwl: the elements of an array live in memory
+allocated by FFTW, At and Set convert from and to complex128, and AtBig and
+SetBig give the exact 113-bit values as big.Float pairs.
+
+	x := fftwq.NewArrayFrom(samples)
+	defer x.Free()
+
+	xhat := fftwq.FFT(x)
+	defer xhat.Free()
+
+	re, im := xhat.AtBig(1)
+
+Arrays are freed by the garbage collector, but as it does not see the memory
+they hold, call Free when done with large arrays. A plan keeps the arrays it
+was created for alive until it is destroyed.
+*/
+package fftwq
@@ -0,0 +1,11 @@
+//go:build cgo && linux && (amd64 || 386)
+
+package fftwq
+
+import "errors"
+
+var (
+	ErrDimensionsMismatch = errors.New("dimensions mismatch")
+	ErrEmpty              = errors.New("empty array")
+	ErrNoPlan             = errors.New("FFTW could not create a plan")
+)
@@ -0,0 +1,154 @@
+//go:build cgo && linux && (amd64 || 386)
+
+package fftwq
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func testAlmostEqual(t *testing.T, a, b float64) {
+	t.Helper()
+
+	if math.Abs(a-b) > 1e-10 {
+		t.Fatalf("expected %v to be almost equal to %v", a, b)
+	}
+}
+
+func TestFFT(t *testing.T) {
+	t.Parallel()
+
+	const n = 16
+
+	src := NewArray(n)
+	defer src.Free()
+
+	for i := range n {
+		src.Set(i, complex(math.Cos(float64(i)/n*math.Pi*2), 0))
+	}
+
+	dst := FFT(src)
+	defer dst.Free()
+
+	for i, x := range dst.Complex128() {
+		want := 0.0
+		if i == 1 || i == n-1 {
+			want = n / 2
+		}
+
+		testAlmostEqual(t, real(x), want)
+		testAlmostEqual(t, imag(x), 0)
+	}
+}
+
+func TestFFTAndIFFTN(t *testing.T) {
+	t.Parallel()
+
+	dims := []int{3, 4, 5}
+
+	src := NewArrayN(dims)
+	x := make([]complex128, 3*4*5)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i)), float64(i%7))
+	}
+	src.CopyFrom(x)
+
+	back := IFFTN(FFTN(src))
+
+	for i, y := range back.Complex128() {
+		testAlmostEqual(t, real(y), float64(len(x))*real(x[i]))
+		testAlmostEqual(t, imag(y), float64(len(x))*imag(x[i]))
+	}
+
+	if got, want := back.At([]int{1, 2, 3}), 60*x[1*20+2*5+3]; math.Abs(real(got-want)) > 1e-10 {
+		t.Fatalf("At = %v, want %v", got, want)
+	}
+}
+
+func TestInPlace2(t *testing.T) {
+	t.Parallel()
+
+	a := NewArray2(4, 6)
+	a.Set(0, 1, 1)
+
+	NewPlan2(a, a, Forward, Estimate).Execute().Destroy()
+
+	// The transform of a shifted impulse has unit magnitude everywhere.
+	for i := range 4 {
+		for j := range 6 {
+			x := a.At(i, j)
+			testAlmostEqual(t, real(x)*real(x)+imag(x)*imag(x), 1)
+		}
+	}
+}
+
+func TestBig(t *testing.T) {
+	t.Parallel()
+
+	a := NewArray3(2, 2, 2)
+
+	// 1 + 2^-60 is not representable as a float64.
+	re := new(big.Float).SetPrec(Precision).SetMantExp(big.NewFloat(1), -60)
+	re.Add(re, big.NewFloat(1))
+	im := big.NewFloat(-0.5)
+
+	a.SetBig(1, 0, 1, re, im)
+
+	if got := a.At(1, 0, 1); got != complex(1, -0.5) {
+		t.Fatalf("At = %v, want (1-0.5i)", got)
+	}
+
+	gotRe, gotIm := a.AtBig(1, 0, 1)
+	if gotRe.Cmp(re) != 0 {
+		t.Fatalf("AtBig real part = %v, want %v", gotRe.Text('x', -1), re.Text('x', -1))
+	}
+	if gotIm.Cmp(im) != 0 {
+		t.Fatalf("AtBig imaginary part = %v, want %v", gotIm, im)
+	}
+
+	a.Set(0, 0, 0, complex(math.NaN(), math.Inf(1)))
+	if nan, inf := a.AtBig(0, 0, 0); nan != nil || !inf.IsInf() {
+		t.Fatalf("AtBig = %v, %v, want nil, +Inf", nan, inf)
+	}
+}
+
+func TestTryNewPlanErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := TryNewPlan(nil, NewArray(4), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	if _, err := TryNewPlan(NewArray(0), NewArray(0), Forward, Estimate); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	if _, err := TryNewPlan2(NewArray2(4, 6), NewArray2(6, 4), Forward, Estimate); !errors.Is(err, ErrDimensionsMismatch) {
+		t.Fatalf("expected ErrDimensionsMismatch, got %v", err)
+	}
+}
+
+func TestFreeKeepsPlannedArrays(t *testing.T) {
+	t.Parallel()
+
+	in, out := NewArray(8), NewArray(8)
+	in.Set(0, 1)
+
+	p := NewPlan(in, out, Forward, Estimate)
+	defer p.Destroy()
+
+	in.Free()
+
+	if in.Len() != 0 {
+		t.Fatalf("Len after Free = %d, want 0", in.Len())
+	}
+
+	// The plan still owns the memory of the freed input.
+	p.Execute()
+
+	for i := range 8 {
+		testAlmostEqual(t, real(out.At(i)), 1)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build linux && (amd64 || 386)
+
+#include <quadmath.h>
+#include <stdlib.h>
+
+#include "helpers.h"
+
+void *gofftwq_alloc(size_t n) { return fftwq_alloc_complex(n); }
+
+void gofftwq_free(void *a) { fftwq_free(a); }
+
+void gofftwq_get(const void *a, size_t i, double *re, double *im) {
+	const fftwq_complex *c = a;
+	*re = (double)c[i][0];
+	*im = (double)c[i][1];
+}
+
+void gofftwq_set(void *a, size_t i, double re, double im) {
+	fftwq_complex *c = a;
+	c[i][0] = re;
+	c[i][1] = im;
+}
+
+void gofftwq_get_all(const void *a, double *x, size_t n) {
+	const fftwq_complex *c = a;
+	for (size_t i = 0; i < n; i++) {
+		x[2 * i] = (double)c[i][0];
+		x[2 * i + 1] = (double)c[i][1];
+	}
+}
+
+void gofftwq_set_all(void *a, const double *x, size_t n) {
+	fftwq_complex *c = a;
+	for (size_t i = 0; i < n; i++) {
+		c[i][0] = x[2 * i];
+		c[i][1] = x[2 * i + 1];
+	}
+}
+
+// gofftwq_format writes part (0 for real, 1 for imaginary) of element i of a
+// as an exact hexadecimal floating-point string.
+int gofftwq_format(const void *a, size_t i, int part, char *buf, size_t size) {
+	const fftwq_complex *c = a;
+	return quadmath_snprintf(buf, size, "%Qa", c[i][part]);
+}
+
+// gofftwq_parse sets part of element i of a to the value of s, and reports
+// whether all of s could be parsed.
+int gofftwq_parse(void *a, size_t i, int part, const char *s) {
+	fftwq_complex *c = a;
+	char *end;
+	__float128 x = strtoflt128(s, &end);
+	if (end == s || *end != 0) {
+		return 0;
+	}
+	c[i][part] = x;
+	return 1;
+}
+
+int gofftwq_mant_dig(void) { return FLT128_MANT_DIG; }
+
+fftwq_plan gofftwq_plan_dft(int rank, const int *n, void *in, void *out, int sign, unsigned flags) {
+	return fftwq_plan_dft(rank, n, in, out, sign, flags);
+}
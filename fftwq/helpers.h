@@ -0,0 +1,17 @@
+// C helpers for package fftwq. Go cannot represent __float128, so the
+// elements of the arrays are only ever touched from C.
+
+#include <stddef.h>
+#include <stdlib.h>
+#include <fftw3.h>
+
+void *gofftwq_alloc(size_t n);
+void gofftwq_free(void *a);
+void gofftwq_get(const void *a, size_t i, double *re, double *im);
+void gofftwq_set(void *a, size_t i, double re, double im);
+void gofftwq_get_all(const void *a, double *x, size_t n);
+void gofftwq_set_all(void *a, const double *x, size_t n);
+int gofftwq_format(const void *a, size_t i, int part, char *buf, size_t size);
+int gofftwq_parse(void *a, size_t i, int part, const char *s);
+int gofftwq_mant_dig(void);
+fftwq_plan gofftwq_plan_dft(int rank, const int *n, void *in, void *out, int sign, unsigned flags);
@@ -0,0 +1,7 @@
+//go:build cgo && linux && (amd64 || 386)
+
+package fftwq
+
+// #cgo CFLAGS: -I/usr/local/include
+// #cgo LDFLAGS: -L/usr/local/lib -lfftw3q -lquadmath -lm
+import "C"
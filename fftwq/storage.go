@@ -0,0 +1,150 @@
+//go:build cgo && linux && (amd64 || 386)
+
+package fftwq
+
+// #include "helpers.h"
+import "C"
+
+import (
+	"math/big"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// storage holds n complex __float128 values allocated by fftwq_alloc_complex.
+type storage struct {
+	ptr unsafe.Pointer
+	n   int
+	// The number of plans using s, and whether its array has been freed, so
+	// that the memory is released once neither uses it.
+	plans    int
+	released bool
+}
+
+func newStorage(n int) *storage {
+	if n < 0 {
+		panic("fftwq: negative array length")
+	}
+	s := &storage{ptr: nil, n: n, plans: 0, released: false}
+	if n > 0 {
+		s.ptr = C.gofftwq_alloc(C.size_t(n))
+		if s.ptr == nil {
+			panic("fftwq: out of memory")
+		}
+	}
+	runtime.SetFinalizer(s, (*storage).free)
+	return s
+}
+
+func (s *storage) free() {
+	if s.ptr != nil {
+		C.gofftwq_free(s.ptr)
+	}
+	s.ptr = nil
+	s.n = 0
+}
+
+// release is called by Free and frees s unless plans still use it.
+func (s *storage) release() {
+	createDestroyMu.Lock()
+	defer createDestroyMu.Unlock()
+	s.released = true
+	if s.plans == 0 {
+		s.free()
+	}
+}
+
+// retain and unretain count the plans using s. They are called with
+// createDestroyMu held.
+func (s *storage) retain() {
+	s.plans++
+}
+
+func (s *storage) unretain() {
+	s.plans--
+	if s.released && s.plans == 0 {
+		s.free()
+	}
+}
+
+// check panics if i is not the index of an element of s, since C does not.
+// A freed storage has no elements.
+func (s *storage) check(i int) {
+	if i < 0 || i >= s.n {
+		panic("fftwq: index out of range")
+	}
+}
+
+func (s *storage) at(i int) complex128 {
+	s.check(i)
+	var re, im C.double
+	C.gofftwq_get(s.ptr, C.size_t(i), &re, &im)
+	return complex(float64(re), float64(im))
+}
+
+func (s *storage) set(i int, x complex128) {
+	s.check(i)
+	C.gofftwq_set(s.ptr, C.size_t(i), C.double(real(x)), C.double(imag(x)))
+}
+
+func (s *storage) complex128s() []complex128 {
+	x := make([]complex128, s.n)
+	if s.n > 0 {
+		C.gofftwq_get_all(s.ptr, (*C.double)(unsafe.Pointer(&x[0])), C.size_t(s.n))
+	}
+	return x
+}
+
+func (s *storage) copyFrom(x []complex128) {
+	if len(x) != s.n {
+		panic("fftwq: length mismatch")
+	}
+	if s.n > 0 {
+		C.gofftwq_set_all(s.ptr, (*C.double)(unsafe.Pointer(&x[0])), C.size_t(s.n))
+	}
+}
+
+// Precision is the number of mantissa bits of a __float128, and so the
+// precision of the big.Float values returned by AtBig.
+//
+//nolint:gochecknoglobals
+var Precision = uint(C.gofftwq_mant_dig())
+
+// atBig converts the exact hexadecimal representation of element i to big.Float.
+func (s *storage) atBig(i int) (re, im *big.Float) {
+	s.check(i)
+	return s.partBig(i, 0), s.partBig(i, 1)
+}
+
+func (s *storage) partBig(i, part int) *big.Float {
+	var buf [128]C.char
+	C.gofftwq_format(s.ptr, C.size_t(i), C.int(part), &buf[0], C.size_t(len(buf)))
+	str := C.GoString(&buf[0])
+	if strings.Contains(str, "nan") {
+		return nil
+	}
+	x, _, err := big.ParseFloat(str, 0, Precision, big.ToNearestEven)
+	if err != nil {
+		panic("fftwq: " + err.Error())
+	}
+	return x
+}
+
+func (s *storage) setBig(i int, re, im *big.Float) {
+	s.check(i)
+	s.setPartBig(i, 0, re)
+	s.setPartBig(i, 1, im)
+}
+
+func (s *storage) setPartBig(i, part int, x *big.Float) {
+	str := "0"
+	if x != nil {
+		str = x.Text('x', -1)
+	}
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	if C.gofftwq_parse(s.ptr, C.size_t(i), C.int(part), cstr) == 0 {
+		panic("fftwq: cannot convert " + str + " to __float128")
+	}
+}
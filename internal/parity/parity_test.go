@@ -0,0 +1,386 @@
+// Package parity runs the same test cases against fftw and fftw32, so that the
+// two packages keep the same API and behavior.
+package parity
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/meko-christian/go-fftw/fftw"
+	"github.com/meko-christian/go-fftw/fftw32"
+)
+
+// backend adapts one of the packages to complex128 slices, so that the cases
+// below are written once.
+type backend struct {
+	name string
+	tol  float64
+
+	fft, ifft        func(x []complex128) []complex128
+	fftTo, ifftTo    func(x []complex128) []complex128
+	fftN, ifftN      func(dims []int, x []complex128) []complex128
+	fftNTo           func(dims []int, x []complex128) []complex128
+	fft2Slice        func(x [][]complex128) ([][]complex128, error)
+	fft3Slice        func(x [][][]complex128) ([][][]complex128, error)
+	rfftN            func(dims []int, x []float64) []complex128
+	irfftN           func(dims []int, x []complex128) []float64
+	planN            func(dims []int, x []complex128) ([]complex128, error)
+	errJagged, errDm error
+}
+
+//nolint:gochecknoglobals
+var backends = []backend{
+	{
+		name: "fftw",
+		tol:  1e-9,
+		fft:  func(x []complex128) []complex128 { return fftw.FFT(&fftw.Array{Elems: x}).Elems },
+		ifft: func(x []complex128) []complex128 { return fftw.IFFT(&fftw.Array{Elems: x}).Elems },
+		fftTo: func(x []complex128) []complex128 {
+			dst := fftw.NewArray(len(x))
+			fftw.FFTTo(dst, &fftw.Array{Elems: x})
+			return dst.Elems
+		},
+		ifftTo: func(x []complex128) []complex128 {
+			dst := fftw.NewArray(len(x))
+			fftw.IFFTTo(dst, &fftw.Array{Elems: x})
+			return dst.Elems
+		},
+		fftN: func(dims []int, x []complex128) []complex128 {
+			return fftw.FFTN(&fftw.ArrayN{N: dims, Elems: x}).Elems
+		},
+		ifftN: func(dims []int, x []complex128) []complex128 {
+			return fftw.IFFTN(&fftw.ArrayN{N: dims, Elems: x}).Elems
+		},
+		fftNTo: func(dims []int, x []complex128) []complex128 {
+			dst := fftw.NewArrayN(dims)
+			fftw.FFTNTo(dst, &fftw.ArrayN{N: dims, Elems: x})
+			return dst.Elems
+		},
+		fft2Slice: func(x [][]complex128) ([][]complex128, error) {
+			a := fftw.NewArray2(len(x), len(x[0]))
+			if err := fftw.CopySlice2(a, x); err != nil {
+				return nil, err
+			}
+			dst := fftw.NewArray2(a.Dims())
+			fftw.FFT2To(dst, a)
+			return dst.Slice(), nil
+		},
+		fft3Slice: func(x [][][]complex128) ([][][]complex128, error) {
+			a := fftw.NewArray3(len(x), len(x[0]), len(x[0][0]))
+			if err := fftw.CopySlice3(a, x); err != nil {
+				return nil, err
+			}
+			dst := fftw.NewArray3(a.Dims())
+			fftw.FFT3To(dst, a)
+			return dst.Slice(), nil
+		},
+		rfftN: func(dims []int, x []float64) []complex128 {
+			return fftw.RFFTN(&fftw.RealArrayN{N: dims, Elems: x}).Elems
+		},
+		irfftN: func(dims []int, x []complex128) []float64 {
+			half := append([]int(nil), dims...)
+			half[len(half)-1] = dims[len(dims)-1]/2 + 1
+			return fftw.IRFFTN(&fftw.ArrayN{N: half, Elems: x}, dims[len(dims)-1]).Elems
+		},
+		planN: func(dims []int, x []complex128) ([]complex128, error) {
+			p := fftw.NewPlanN(fftw.NewArrayN(dims), fftw.NewArrayN(dims), fftw.Forward, fftw.Estimate)
+			defer p.Destroy()
+			dst := fftw.NewArrayN(dims)
+			err := p.ExecuteOnN(&fftw.ArrayN{N: dims, Elems: x}, dst)
+			return dst.Elems, err
+		},
+		errJagged: fftw.ErrJaggedArray,
+		errDm:     fftw.ErrDimensionsMismatch,
+	},
+	{
+		name: "fftw32",
+		tol:  1e-3,
+		fft:  func(x []complex128) []complex128 { return to128(fftw32.FFT(&fftw32.Array{Elems: to64(x)}).Elems) },
+		ifft: func(x []complex128) []complex128 { return to128(fftw32.IFFT(&fftw32.Array{Elems: to64(x)}).Elems) },
+		fftTo: func(x []complex128) []complex128 {
+			dst := fftw32.NewArray(len(x))
+			fftw32.FFTTo(dst, &fftw32.Array{Elems: to64(x)})
+			return to128(dst.Elems)
+		},
+		ifftTo: func(x []complex128) []complex128 {
+			dst := fftw32.NewArray(len(x))
+			fftw32.IFFTTo(dst, &fftw32.Array{Elems: to64(x)})
+			return to128(dst.Elems)
+		},
+		fftN: func(dims []int, x []complex128) []complex128 {
+			return to128(fftw32.FFTN(&fftw32.ArrayN{N: dims, Elems: to64(x)}).Elems)
+		},
+		ifftN: func(dims []int, x []complex128) []complex128 {
+			return to128(fftw32.IFFTN(&fftw32.ArrayN{N: dims, Elems: to64(x)}).Elems)
+		},
+		fftNTo: func(dims []int, x []complex128) []complex128 {
+			dst := fftw32.NewArrayN(dims)
+			fftw32.FFTNTo(dst, &fftw32.ArrayN{N: dims, Elems: to64(x)})
+			return to128(dst.Elems)
+		},
+		fft2Slice: func(x [][]complex128) ([][]complex128, error) {
+			a := fftw32.NewArray2(len(x), len(x[0]))
+			src := make([][]complex64, len(x))
+			for i := range x {
+				src[i] = to64(x[i])
+			}
+			if err := fftw32.CopySlice2(a, src); err != nil {
+				return nil, err
+			}
+			dst := fftw32.NewArray2(a.Dims())
+			fftw32.FFT2To(dst, a)
+			out := make([][]complex128, len(x))
+			for i, row := range dst.Slice() {
+				out[i] = to128(row)
+			}
+			return out, nil
+		},
+		fft3Slice: func(x [][][]complex128) ([][][]complex128, error) {
+			a := fftw32.NewArray3(len(x), len(x[0]), len(x[0][0]))
+			src := make([][][]complex64, len(x))
+			for i := range x {
+				src[i] = make([][]complex64, len(x[i]))
+				for j := range x[i] {
+					src[i][j] = to64(x[i][j])
+				}
+			}
+			if err := fftw32.CopySlice3(a, src); err != nil {
+				return nil, err
+			}
+			dst := fftw32.NewArray3(a.Dims())
+			fftw32.FFT3To(dst, a)
+			out := make([][][]complex128, len(x))
+			for i, plane := range dst.Slice() {
+				out[i] = make([][]complex128, len(plane))
+				for j, row := range plane {
+					out[i][j] = to128(row)
+				}
+			}
+			return out, nil
+		},
+		rfftN: func(dims []int, x []float64) []complex128 {
+			src := make([]float32, len(x))
+			for i := range x {
+				src[i] = float32(x[i])
+			}
+			return to128(fftw32.RFFTN(&fftw32.RealArrayN{N: dims, Elems: src}).Elems)
+		},
+		irfftN: func(dims []int, x []complex128) []float64 {
+			half := append([]int(nil), dims...)
+			half[len(half)-1] = dims[len(dims)-1]/2 + 1
+			res := fftw32.IRFFTN(&fftw32.ArrayN{N: half, Elems: to64(x)}, dims[len(dims)-1]).Elems
+			out := make([]float64, len(res))
+			for i := range res {
+				out[i] = float64(res[i])
+			}
+			return out
+		},
+		planN: func(dims []int, x []complex128) ([]complex128, error) {
+			p := fftw32.NewPlanN(fftw32.NewArrayN(dims), fftw32.NewArrayN(dims), fftw32.Forward, fftw32.Estimate)
+			defer p.Destroy()
+			dst := fftw32.NewArrayN(dims)
+			err := p.ExecuteOnN(&fftw32.ArrayN{N: dims, Elems: to64(x)}, dst)
+			return to128(dst.Elems), err
+		},
+		errJagged: fftw32.ErrJaggedArray,
+		errDm:     fftw32.ErrDimensionsMismatch,
+	},
+}
+
+func to64(x []complex128) []complex64 {
+	y := make([]complex64, len(x))
+	for i := range x {
+		y[i] = complex64(x[i])
+	}
+	return y
+}
+
+func to128(x []complex64) []complex128 {
+	y := make([]complex128, len(x))
+	for i := range x {
+		y[i] = complex128(x[i])
+	}
+	return y
+}
+
+// dft computes the DFT of the row-major array x with dimensions dims directly
+// from its definition.
+func dft(dims []int, x []complex128, sign float64) []complex128 {
+	y := append([]complex128(nil), x...)
+	stride := 1
+	for d := len(dims) - 1; d >= 0; d-- {
+		n := dims[d]
+		next := make([]complex128, len(y))
+		for i := range y {
+			k := i / stride % n
+			base := i - k*stride
+			var sum complex128
+			for j := range n {
+				w := cmplx.Exp(complex(0, sign*2*math.Pi*float64(j*k)/float64(n)))
+				sum += y[base+j*stride] * w
+			}
+			next[i] = sum
+		}
+		y = next
+		stride *= n
+	}
+	return y
+}
+
+func signal(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i)*0.7), math.Cos(float64(i*i)*0.3)-0.5)
+	}
+	return x
+}
+
+func expectClose(t *testing.T, b backend, got, want []complex128) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d elements, want %d", b.name, len(got), len(want))
+	}
+	for i := range want {
+		if cmplx.Abs(got[i]-want[i]) > b.tol*(1+cmplx.Abs(want[i])) {
+			t.Fatalf("%s: element %d is %v, want %v", b.name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range backends {
+		for _, n := range []int{1, 2, 7, 16, 30} {
+			x := signal(n)
+			expectClose(t, b, b.fft(x), dft([]int{n}, x, -1))
+			expectClose(t, b, b.ifft(x), dft([]int{n}, x, 1))
+			expectClose(t, b, b.fftTo(x), dft([]int{n}, x, -1))
+			expectClose(t, b, b.ifftTo(x), dft([]int{n}, x, 1))
+		}
+	}
+}
+
+func TestFFTN(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range backends {
+		for _, dims := range [][]int{{5}, {3, 4}, {2, 3, 4}, {2, 3, 2, 3}} {
+			x := signal(prod(dims))
+			expectClose(t, b, b.fftN(dims, x), dft(dims, x, -1))
+			expectClose(t, b, b.ifftN(dims, x), dft(dims, x, 1))
+			expectClose(t, b, b.fftNTo(dims, x), dft(dims, x, -1))
+
+			got, err := b.planN(dims, x)
+			if err != nil {
+				t.Fatalf("%s: ExecuteOnN: %v", b.name, err)
+			}
+			expectClose(t, b, got, dft(dims, x, -1))
+		}
+	}
+}
+
+func TestFFTSlices(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range backends {
+		x := signal(3 * 4 * 5)
+
+		x2 := make([][]complex128, 3)
+		for i := range x2 {
+			x2[i] = x[i*20 : (i+1)*20]
+		}
+		got2, err := b.fft2Slice(x2)
+		if err != nil {
+			t.Fatalf("%s: %v", b.name, err)
+		}
+		want := dft([]int{3, 20}, x, -1)
+		for i := range got2 {
+			expectClose(t, b, got2[i], want[i*20:(i+1)*20])
+		}
+
+		x3 := make([][][]complex128, 3)
+		for i := range x3 {
+			x3[i] = make([][]complex128, 4)
+			for j := range x3[i] {
+				x3[i][j] = x[(i*4+j)*5 : (i*4+j+1)*5]
+			}
+		}
+		got3, err := b.fft3Slice(x3)
+		if err != nil {
+			t.Fatalf("%s: %v", b.name, err)
+		}
+		want = dft([]int{3, 4, 5}, x, -1)
+		for i := range got3 {
+			for j := range got3[i] {
+				expectClose(t, b, got3[i][j], want[(i*4+j)*5:(i*4+j+1)*5])
+			}
+		}
+	}
+}
+
+func TestCopySliceErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range backends {
+		jagged := [][]complex128{make([]complex128, 4), make([]complex128, 3)}
+		if _, err := b.fft2Slice(jagged); !errors.Is(err, b.errJagged) {
+			t.Fatalf("%s: expected ErrJaggedArray, got %v", b.name, err)
+		}
+
+		jagged3 := [][][]complex128{
+			{make([]complex128, 2), make([]complex128, 2)},
+			{make([]complex128, 2)},
+		}
+		if _, err := b.fft3Slice(jagged3); !errors.Is(err, b.errJagged) {
+			t.Fatalf("%s: expected ErrJaggedArray, got %v", b.name, err)
+		}
+
+		if _, err := b.planN([]int{2, 3}, make([]complex128, 5)); !errors.Is(err, b.errDm) {
+			t.Fatalf("%s: expected ErrDimensionsMismatch, got %v", b.name, err)
+		}
+	}
+}
+
+func TestRFFTN(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range backends {
+		for _, dims := range [][]int{{6}, {3, 5}, {2, 3, 4}} {
+			x := signal(prod(dims))
+			re := make([]float64, len(x))
+			for i := range x {
+				re[i] = real(x[i])
+				x[i] = complex(re[i], 0)
+			}
+
+			n := dims[len(dims)-1]
+			full := dft(dims, x, -1)
+			var want []complex128
+			for i := 0; i < len(full); i += n {
+				want = append(want, full[i:i+n/2+1]...)
+			}
+
+			spec := b.rfftN(dims, re)
+			expectClose(t, b, spec, want)
+
+			back := b.irfftN(dims, spec)
+			for i := range re {
+				if math.Abs(back[i]-float64(len(re))*re[i]) > b.tol*float64(len(re)) {
+					t.Fatalf("%s: IRFFTN element %d is %v, want %v", b.name, i, back[i], float64(len(re))*re[i])
+				}
+			}
+		}
+	}
+}
+
+func prod(dims []int) int {
+	n := 1
+	for _, d := range dims {
+		n *= d
+	}
+	return n
+}
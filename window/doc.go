@@ -0,0 +1,20 @@
+/*
+Package window computes the window functions that taper a signal before its
+Fourier transform, to reduce the spectral leakage of its discontinuity at the
+ends.
+
+Each window comes in two forms: Symmetric, for filter design, and Periodic, for
+spectral analysis, which is what numpy and scipy call sym=False:
+
+	w := window.Hann(x.Len(), window.Periodic)
+	window.Apply(w, x.Elems)
+	xhat := fftw.FFT(x)
+
+Apply and ApplyAxis work on the Elems of the arrays of packages fftw, fftw32 and
+fft, real or complex. CoherentGain and ENBW give the amplitude and noise scaling
+of a window, to correct the spectrum:
+
+	amplitude := 2 * cmplx.Abs(xhat.Elems[k]) / (float64(len(w)) * w.CoherentGain())
+	noiseBW := w.ENBW() * sampleRate / float64(len(w))
+*/
+package window
@@ -0,0 +1,323 @@
+package window
+
+import (
+	"fmt"
+	"math"
+)
+
+// Window holds the coefficients of a window function.
+type Window []float64
+
+// Symmetry selects the form of a window.
+type Symmetry int
+
+const (
+	// Symmetric windows are symmetric about their center, for filter design.
+	Symmetric Symmetry = iota
+	// Periodic windows are the first n coefficients of the symmetric window of
+	// length n+1, so that they repeat seamlessly, for spectral analysis.
+	Periodic
+)
+
+func (s Symmetry) String() string {
+	switch s {
+	case Symmetric:
+		return "Symmetric"
+	case Periodic:
+		return "Periodic"
+	}
+	return fmt.Sprintf("Symmetry(%d)", int(s))
+}
+
+// Rectangular returns the window of n ones, which leaves a signal unchanged.
+func Rectangular(n int) Window {
+	return build(n, Symmetric, func(m int) Window {
+		w := make(Window, m)
+		for i := range w {
+			w[i] = 1
+		}
+		return w
+	})
+}
+
+// Hann returns the Hann window of length n.
+func Hann(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.5, 0.5)
+}
+
+// Hamming returns the Hamming window of length n.
+func Hamming(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.54, 0.46)
+}
+
+// Blackman returns the Blackman window of length n.
+func Blackman(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.42, 0.5, 0.08)
+}
+
+// BlackmanHarris returns the minimum four-term Blackman-Harris window of
+// length n, whose sidelobes are below -92 dB.
+func BlackmanHarris(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.35875, 0.48829, 0.14128, 0.01168)
+}
+
+// FlatTop returns the flat-top window of length n, whose flat main lobe gives
+// accurate amplitudes of sinusoids between bins.
+func FlatTop(n int, sym Symmetry) Window {
+	return cosineSum(n, sym, 0.21557895, 0.41663158, 0.277263158, 0.083578947, 0.006947368)
+}
+
+// Kaiser returns the Kaiser window of length n with shape parameter beta >= 0.
+// A beta of 0 gives the rectangular window; larger values widen the main lobe
+// and lower the sidelobes.
+func Kaiser(n int, beta float64, sym Symmetry) Window {
+	if beta < 0 {
+		panic("window: Kaiser beta must be >= 0")
+	}
+	return build(n, sym, func(m int) Window {
+		w := make(Window, m)
+		for i := range w {
+			x := 2*float64(i)/float64(m-1) - 1
+			w[i] = besselI0(beta*math.Sqrt(max(0, 1-x*x))) / besselI0(beta)
+		}
+		return w
+	})
+}
+
+// Tukey returns the Tukey, or tapered cosine, window of length n, whose
+// fraction alpha in [0, 1] is tapered with half a Hann window at each end.
+// An alpha of 0 gives the rectangular window and 1 the Hann window.
+func Tukey(n int, alpha float64, sym Symmetry) Window {
+	if alpha < 0 || alpha > 1 {
+		panic("window: Tukey alpha must be between 0 and 1")
+	}
+	return build(n, sym, func(m int) Window {
+		w := make(Window, m)
+		for i := range w {
+			x := float64(i) / float64(m-1)
+			switch {
+			case x < alpha/2:
+				w[i] = 0.5 * (1 + math.Cos(math.Pi*(2*x/alpha-1)))
+			case x > 1-alpha/2:
+				w[i] = 0.5 * (1 + math.Cos(math.Pi*(2*x/alpha-2/alpha+1)))
+			default:
+				w[i] = 1
+			}
+		}
+		return w
+	})
+}
+
+// Gaussian returns the Gaussian window of length n with standard deviation
+// sigma > 0, in samples.
+func Gaussian(n int, sigma float64, sym Symmetry) Window {
+	if sigma <= 0 {
+		panic("window: Gaussian sigma must be > 0")
+	}
+	return build(n, sym, func(m int) Window {
+		w := make(Window, m)
+		for i := range w {
+			x := (float64(i) - float64(m-1)/2) / sigma
+			w[i] = math.Exp(-0.5 * x * x)
+		}
+		return w
+	})
+}
+
+// Chebyshev returns the Dolph-Chebyshev window of length n, whose sidelobes
+// are all attenuation dB below its main lobe, the narrowest possible for that
+// level.
+func Chebyshev(n int, attenuation float64, sym Symmetry) Window {
+	if attenuation <= 0 {
+		panic("window: Chebyshev attenuation must be > 0")
+	}
+	return build(n, sym, func(m int) Window {
+		// The window is the inverse DFT of the Chebyshev polynomial of order
+		// m-1, sampled so that its sidelobes all reach 1 and its peak r.
+		order := float64(m - 1)
+		r := math.Pow(10, attenuation/20)
+		beta := math.Cosh(math.Acosh(r) / order)
+		p := make([]complex128, m)
+		for k := range p {
+			x := beta * math.Cos(math.Pi*float64(k)/float64(m))
+			var t float64
+			switch {
+			case x > 1:
+				t = math.Cosh(order * math.Acosh(x))
+			case x < -1:
+				t = math.Cosh(order * math.Acosh(-x))
+				if m%2 == 0 {
+					t = -t
+				}
+			default:
+				t = math.Cos(order * math.Acos(x))
+			}
+			p[k] = complex(t, 0)
+			if m%2 == 0 {
+				// A half-sample delay centers the window of even length.
+				s, c := math.Sincos(math.Pi * float64(k) / float64(m))
+				p[k] *= complex(c, s)
+			}
+		}
+
+		w := make(Window, m)
+		for i := range w {
+			// The samples of p at i and m-i are conjugate, so w is real and
+			// symmetric; index i of w is at offset i - (m-1)/2 from its center.
+			j := (i - (m-1)/2 + m) % m
+			var sum float64
+			for k, pk := range p {
+				s, c := math.Sincos(-2 * math.Pi * float64(j*k%m) / float64(m))
+				sum += real(pk)*c - imag(pk)*s
+			}
+			w[i] = sum
+		}
+		if m%2 == 0 {
+			// Offsets of -m/2 are the last element, not the first.
+			for i := range m / 2 {
+				w[i] = w[m-1-i]
+			}
+		}
+		peak := 0.0
+		for _, x := range w {
+			peak = max(peak, x)
+		}
+		for i := range w {
+			w[i] /= peak
+		}
+		return w
+	})
+}
+
+// cosineSum returns the window of length n whose coefficients are
+// a[0] - a[1]cos(2πi/m) + a[2]cos(4πi/m) - ..., with m = n-1 for symmetric
+// windows.
+func cosineSum(n int, sym Symmetry, a ...float64) Window {
+	return build(n, sym, func(m int) Window {
+		w := make(Window, m)
+		for i := range w {
+			x := 2 * math.Pi * float64(i) / float64(m-1)
+			sign := 1.0
+			for k, ak := range a {
+				w[i] += sign * ak * math.Cos(float64(k)*x)
+				sign = -sign
+			}
+		}
+		return w
+	})
+}
+
+// build returns the window of length n and the given symmetry from symmetric,
+// which returns the symmetric window of length m > 1.
+func build(n int, sym Symmetry, symmetric func(m int) Window) Window {
+	switch {
+	case n <= 0:
+		panic("window: n must be > 0")
+	case sym != Symmetric && sym != Periodic:
+		panic(fmt.Sprintf("window: invalid %v", sym))
+	case n == 1:
+		return Window{1}
+	case sym == Periodic:
+		return symmetric(n + 1)[:n]
+	}
+	return symmetric(n)
+}
+
+// besselI0 returns the modified Bessel function of the first kind of order 0.
+func besselI0(x float64) float64 {
+	// The terms of the power series are ((x/2)^k / k!)^2.
+	sum, term := 1.0, 1.0
+	for k := 1; term > sum*1e-17; k++ {
+		term *= (x / 2 / float64(k)) * (x / 2 / float64(k))
+		sum += term
+	}
+	return sum
+}
+
+// CoherentGain returns the mean of the coefficients of w, the factor by which
+// it scales the amplitude of a sinusoid at the center of a bin.
+func (w Window) CoherentGain() float64 {
+	var sum float64
+	for _, x := range w {
+		sum += x
+	}
+	return sum / float64(len(w))
+}
+
+// ENBW returns the equivalent noise bandwidth of w in bins: the width of the
+// rectangular filter that passes as much white noise as w, relative to its peak.
+func (w Window) ENBW() float64 {
+	var sum, sum2 float64
+	for _, x := range w {
+		sum += x
+		sum2 += x * x
+	}
+	return float64(len(w)) * sum2 / (sum * sum)
+}
+
+// Elem is the element type of the arrays a window applies to.
+type Elem interface {
+	float32 | float64 | complex64 | complex128
+}
+
+// Apply multiplies x by w in place, element by element. They must have the same
+// length, as with the Elems of an Array or RealArray:
+//
+//	window.Apply(w, a.Elems)
+func Apply[T Elem](w Window, x []T) {
+	if len(x) != len(w) {
+		panic(fmt.Sprintf("window: length %d, want %d", len(x), len(w)))
+	}
+	applyLine(w, x, 0, 1)
+}
+
+// ApplyAxis multiplies each line along the axis of the row-major array x with
+// dimensions dims by w in place, as with the Elems of an Array2 or ArrayN:
+//
+//	window.ApplyAxis(w, a.Elems, a.N[:], 0) // down the columns of an Array2
+func ApplyAxis[T Elem](w Window, x []T, dims []int, axis int) {
+	if axis < 0 || axis >= len(dims) {
+		panic(fmt.Sprintf("window: axis %d out of range for %d dimensions", axis, len(dims)))
+	}
+	if dims[axis] != len(w) {
+		panic(fmt.Sprintf("window: length %d along axis %d, want %d", dims[axis], axis, len(w)))
+	}
+	size, stride := 1, 1
+	for i, n := range dims {
+		size *= n
+		if i > axis {
+			stride *= n
+		}
+	}
+	if len(x) != size {
+		panic(fmt.Sprintf("window: %d elements, want %d for dimensions %v", len(x), size, dims))
+	}
+	for base := 0; base < size; base += len(w) * stride {
+		for i := base; i < base+stride; i++ {
+			applyLine(w, x, i, stride)
+		}
+	}
+}
+
+// applyLine multiplies the line of x that starts at start with the given stride
+// by w.
+func applyLine[T Elem](w Window, x []T, start, stride int) {
+	switch x := any(x).(type) {
+	case []float32:
+		for i, c := range w {
+			x[start+i*stride] *= float32(c)
+		}
+	case []float64:
+		for i, c := range w {
+			x[start+i*stride] *= c
+		}
+	case []complex64:
+		for i, c := range w {
+			x[start+i*stride] *= complex(float32(c), 0)
+		}
+	case []complex128:
+		for i, c := range w {
+			x[start+i*stride] *= complex(c, 0)
+		}
+	}
+}
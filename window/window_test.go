@@ -0,0 +1,191 @@
+package window
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func testClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+
+	if math.Abs(got-want) > 1e-9*(1+math.Abs(want)) {
+		t.Fatalf("%s: got %v, want %v", name, got, want)
+	}
+}
+
+func expectPanic(t *testing.T, name string, panicFn func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("%s: expect panic", name)
+		}
+	}()
+	panicFn()
+}
+
+func TestWindowValues(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		w    Window
+		want []float64
+	}{
+		{"rectangular", Rectangular(3), []float64{1, 1, 1}},
+		{"hann", Hann(5, Symmetric), []float64{0, 0.5, 1, 0.5, 0}},
+		{"periodic hann", Hann(4, Periodic), []float64{0, 0.5, 1, 0.5}},
+		{"hamming", Hamming(3, Symmetric), []float64{0.08, 1, 0.08}},
+		{"blackman", Blackman(3, Symmetric), []float64{0, 1, 0}},
+		{"blackman-harris", BlackmanHarris(3, Symmetric), []float64{6e-5, 1, 6e-5}},
+		{"flat-top", FlatTop(3, Symmetric), []float64{-0.000421051, 1.000000003, -0.000421051}},
+		{"kaiser 0", Kaiser(4, 0, Symmetric), []float64{1, 1, 1, 1}},
+		{"kaiser", Kaiser(3, 2, Symmetric), []float64{1 / besselI0(2), 1, 1 / besselI0(2)}},
+		{"tukey 0", Tukey(4, 0, Periodic), []float64{1, 1, 1, 1}},
+		{"tukey 1", Tukey(5, 1, Symmetric), []float64{0, 0.5, 1, 0.5, 0}},
+		{"tukey", Tukey(9, 0.5, Symmetric), []float64{0, 0.5, 1, 1, 1, 1, 1, 0.5, 0}},
+		{"gaussian", Gaussian(3, 1, Symmetric), []float64{math.Exp(-0.5), 1, math.Exp(-0.5)}},
+		{"chebyshev", Chebyshev(3, 20, Symmetric), []float64{11.0 / 18, 1, 11.0 / 18}},
+		{"length 1", Blackman(1, Periodic), []float64{1}},
+	} {
+		if len(tc.w) != len(tc.want) {
+			t.Fatalf("%s: length %d, want %d", tc.name, len(tc.w), len(tc.want))
+		}
+		for i := range tc.want {
+			testClose(t, tc.name, tc.w[i], tc.want[i])
+		}
+	}
+
+	testClose(t, "besselI0(0)", besselI0(0), 1)
+	testClose(t, "besselI0(5)", besselI0(5), 27.239871823604442)
+}
+
+func TestWindowSymmetry(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{2, 7, 16, 33} {
+		for name, f := range map[string]func(int, Symmetry) Window{
+			"hann":            Hann,
+			"hamming":         Hamming,
+			"blackman":        Blackman,
+			"blackman-harris": BlackmanHarris,
+			"flat-top":        FlatTop,
+			"kaiser":          func(n int, s Symmetry) Window { return Kaiser(n, 8.6, s) },
+			"tukey":           func(n int, s Symmetry) Window { return Tukey(n, 0.3, s) },
+			"gaussian":        func(n int, s Symmetry) Window { return Gaussian(n, 3, s) },
+			"chebyshev":       func(n int, s Symmetry) Window { return Chebyshev(n, 80, s) },
+		} {
+			w := f(n, Symmetric)
+			for i := range w {
+				testClose(t, name, w[i], w[n-1-i])
+			}
+
+			// The periodic window is the symmetric one of length n+1, truncated.
+			p, s := f(n, Periodic), f(n+1, Symmetric)
+			for i := range p {
+				testClose(t, name, p[i], s[i])
+			}
+		}
+	}
+}
+
+func TestChebyshevSidelobes(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{16, 31} {
+		const attenuation = 60
+
+		w := Chebyshev(n, attenuation, Symmetric)
+
+		// Sample the spectrum of w finely, from its peak at 0 past the main lobe
+		// to the Nyquist frequency.
+		const steps = 4096
+		spectrum := make([]float64, steps+1)
+		for s := range spectrum {
+			var sum complex128
+			for i, x := range w {
+				sum += complex(x, 0) * cmplx.Rect(1, -math.Pi*float64(s*i)/steps)
+			}
+			spectrum[s] = cmplx.Abs(sum)
+		}
+
+		s := 1
+		for s < steps && spectrum[s+1] < spectrum[s] {
+			s++
+		}
+		sidelobe := 0.0
+		for _, a := range spectrum[s:] {
+			sidelobe = max(sidelobe, a)
+		}
+		if level := 20 * math.Log10(sidelobe/spectrum[0]); math.Abs(level+attenuation) > 0.1 {
+			t.Fatalf("n=%d: sidelobes at %.2f dB, want %d dB", n, level, -attenuation)
+		}
+	}
+}
+
+func TestWindowMetrics(t *testing.T) {
+	t.Parallel()
+
+	const n = 1024
+
+	for _, tc := range []struct {
+		name       string
+		w          Window
+		gain, enbw float64
+	}{
+		{"rectangular", Rectangular(n), 1, 1},
+		{"hann", Hann(n, Periodic), 0.5, 1.5},
+		{"hamming", Hamming(n, Periodic), 0.54, 1.362826},
+		{"blackman", Blackman(n, Periodic), 0.42, 1.726757},
+		{"blackman-harris", BlackmanHarris(n, Periodic), 0.35875, 2.004353},
+		{"flat-top", FlatTop(n, Periodic), 0.21557895, 3.770246},
+	} {
+		if g := tc.w.CoherentGain(); math.Abs(g-tc.gain) > 1e-6 {
+			t.Fatalf("%s: coherent gain %v, want %v", tc.name, g, tc.gain)
+		}
+		if b := tc.w.ENBW(); math.Abs(b-tc.enbw) > 1e-4 {
+			t.Fatalf("%s: ENBW %v, want %v", tc.name, b, tc.enbw)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	w := Window{0, 0.5, 1}
+
+	x := []complex64{2, 2i, 2}
+	Apply(w, x)
+	if x[0] != 0 || x[1] != 1i || x[2] != 2 {
+		t.Fatalf("Apply = %v", x)
+	}
+
+	// A 2 x 3 array, along each axis.
+	y := []float64{1, 1, 1, 1, 1, 1}
+	ApplyAxis(w, y, []int{2, 3}, 1)
+	ApplyAxis(Window{2, 3}, y, []int{2, 3}, 0)
+	for i, want := range []float64{0, 1, 2, 0, 1.5, 3} {
+		testClose(t, "ApplyAxis", y[i], want)
+	}
+
+	z := make([]complex128, 3*4*5)
+	for i := range z {
+		z[i] = 1
+	}
+	ApplyAxis(Hann(4, Periodic), z, []int{3, 4, 5}, 1)
+	for i, v := range z {
+		testClose(t, "ApplyAxis", real(v), Hann(4, Periodic)[i/5%4])
+	}
+
+	expectPanic(t, "length mismatch", func() { Apply(w, []float32{1, 2}) })
+	expectPanic(t, "axis out of range", func() { ApplyAxis(w, y, []int{2, 3}, 2) })
+	expectPanic(t, "axis length mismatch", func() { ApplyAxis(w, y, []int{2, 3}, 0) })
+	expectPanic(t, "size mismatch", func() { ApplyAxis(w, y, []int{3, 3}, 1) })
+	expectPanic(t, "empty window", func() { Hann(0, Periodic) })
+	expectPanic(t, "invalid symmetry", func() { Hann(4, Symmetry(2)) })
+	expectPanic(t, "negative beta", func() { Kaiser(4, -1, Periodic) })
+	expectPanic(t, "alpha out of range", func() { Tukey(4, 1.5, Periodic) })
+	expectPanic(t, "zero sigma", func() { Gaussian(4, 0, Periodic) })
+	expectPanic(t, "zero attenuation", func() { Chebyshev(4, 0, Periodic) })
+}